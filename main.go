@@ -1,86 +1,147 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
-	"math/rand"
+	"os"
+	"os/signal"
 	"time"
 
+	"bitcrawler/pkg/config"
 	"bitcrawler/pkg/entity"
 	"bitcrawler/pkg/game"
 	"bitcrawler/pkg/gear"
+	"bitcrawler/pkg/input"
 	"bitcrawler/pkg/logging"
-	"bitcrawler/pkg/room"
+	"bitcrawler/pkg/render"
+	"bitcrawler/pkg/terminal"
 )
 
 func main() {
+	cfg, err := config.Parse(os.Args[0], os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Invalid configuration:", err)
+		os.Exit(2)
+	}
+
 	// initialize the logger
-	logger, err := logging.NewLogger(logging.LogLevelDebug)
+	logLevel, _ := logging.ParseLogLevel(cfg.LogLevel)
+	logger, err := logging.NewLogger(logLevel, cfg.LogFile)
 	if err != nil {
 		panic("Failed to initialize logger: " + err.Error())
 	}
 
+	renderer, err := render.New(cfg.Renderer, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	// Commands come from the terminal unless a replay is requested,
+	// key mode is set up last so nothing exits before it is restored
+	restoreTerminal := func() error { return nil }
+	var source input.Source = input.NewTerminal(os.Stdin)
+	switch {
+	case cfg.Replay != "":
+		if source, err = input.NewFile(cfg.Replay); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	case cfg.Input == input.ModeKeys:
+		restore, err := terminal.EnableKeyMode()
+		if err != nil {
+			logger.LogMessage(logging.LogLevelWarning, "Falling back to text input: "+err.Error())
+			break
+		}
+		restoreTerminal = restore
+		defer restore()
+		restoreOnInterrupt(restore)
+		source = input.NewKeys(os.Stdin, os.Stdout, cfg.KeyBindings)
+	}
+
+	// Game loop, a new run starts whenever the player asks to restart
+	for {
+		gameBoard := newGame(cfg, logger, source, renderer)
+
+		// Initialize the first level of the dungeon
+		if err := gameBoard.GenerateLevel(1); err != nil {
+			restoreTerminal()
+			panic("Cannot initialize level: " + err.Error())
+		}
+		logger.LogMessage(logging.LogLevelDebug, "Game board initialized")
+
+		logger.LogMessage(logging.LogLevelDebug, "Game started")
+		for !gameBoard.GameOver {
+			gameBoard.ProcessTurn()
+		}
+
+		if !gameBoard.Restart {
+			break
+		}
+	}
+}
+
+// newGame sets up a fresh run with a new player and random source.
+func newGame(cfg config.Config, logger *logging.Logger, source input.Source, renderer render.Renderer) *game.Game {
 	// Initialize start time
 	startTime := time.Now()
 	logger.LogMessage(logging.LogLevelInfo, "Game started")
 
-	// Initialize the room for the game
-	rm := room.NewRoom(24, 8, 1)
-	logger.LogMessage(logging.LogLevelDebug,
-		fmt.Sprintf("Room initialized with dimensions: %d x %d", rm.Width, rm.Height))
+	// Initialize the random source every part of the game draws from
+	seed, rng, randSource := game.NewRand(cfg.Seed)
+	logger.LogMessage(logging.LogLevelInfo, fmt.Sprintf("Seed: %d", seed))
 
-	// Find an empty space in the room to place the player
-	randX, randY := rm.FindEmptySpace()
-	if randX == -1 && randY == -1 {
-		panic("Cannot initialize room, no empty space found!")
-	}
-
-	// Initialize our player character with our random coordinates
+	// Initialize our player character, the level places them in the room
 	player := &entity.Character{
-		X:         randX,
-		Y:         randY,
 		ID:        entity.ObjPlayer,
-		Name:      "Hero",
-		HP:        100,
-		Attack:    10,
-		Defense:   5,
+		Name:      cfg.Player.Name,
+		HP:        cfg.Player.HP,
+		MaxHP:     cfg.Player.HP,
+		Attack:    cfg.Player.Attack,
+		Defense:   cfg.Player.Defense,
 		Visual:    '@',
-		Abilities: []entity.Ability{gear.AbilityMightStrength},
-	}
-	logger.LogMessage(logging.LogLevelDebug,
-		fmt.Sprintf("Player initialized at coordinates: (%d, %d)", randX, randY))
-
-	// Add the player to the room
-	rm.AddEntity(&room.Coordinate{X: randX, Y: randY, Entity: player})
-	logger.LogMessage(logging.LogLevelDebug, "Player added to the room")
-
-	// Find the farthest distance from the player coordinates
-	exitX, exitY := rm.FindFarthestDistance(randX, randY, false)
-	logger.LogMessage(logging.LogLevelDebug,
-		fmt.Sprintf("Farthest exit found at coordinates: (%d, %d)", exitX, exitY))
-
-	// Create the exit
-	exit := &entity.Character{
-		X:    exitX,
-		Y:    exitY,
-		ID:   entity.ObjExit,
-		Name: "Exit",
-	}
-	logger.LogMessage(logging.LogLevelDebug, "Exit created")
-
-	// Add the exit to the room with the farthest coordinates
-	rm.AddEntity(&room.Coordinate{X: exitX, Y: exitY, Entity: exit})
-	logger.LogMessage(logging.LogLevelDebug, "Exit added to the room")
+		Abilities: append([]entity.Ability{gear.AbilityMightStrength}, gear.Spells...),
 
-	// Setup our enemies
-	goblinEnemyCount := rand.Intn(2)
-	enemies := rm.PlaceGoblinPack(goblinEnemyCount, true)
+		Mana:       cfg.Player.Mana,
+		MaxMana:    cfg.Player.Mana,
+		Stamina:    cfg.Player.Stamina,
+		MaxStamina: cfg.Player.Stamina,
 
-	gameBoard := &game.Game{StartTime: startTime, Logger: logger, Room: rm, Player: player, Enemies: enemies}
-	logger.LogMessage(logging.LogLevelDebug, "Game board initialized")
+		InventoryCapacity: cfg.Player.InventoryCapacity,
+	}
+	logger.LogMessage(logging.LogLevelDebug, "Player initialized")
 
-	// Game loop
-	logger.LogMessage(logging.LogLevelDebug, "Game started")
-	for {
-		gameBoard.ProcessTurn()
+	return &game.Game{
+		StartTime:    startTime,
+		Seed:         seed,
+		Rand:         rng,
+		RandSource:   randSource,
+		Logger:       logger,
+		Input:        source,
+		Renderer:     renderer,
+		Player:       player,
+		RoomWidth:    cfg.Width,
+		RoomHeight:   cfg.Height,
+		Algorithm:    cfg.Algorithm,
+		FinalLevel:   cfg.FinalLevel,
+		EnemyDensity: cfg.EnemyDensity,
+		Difficulty:   cfg.Difficulty,
+		Pathfinding:  cfg.Pathfinding,
 	}
 }
+
+// restoreOnInterrupt puts the terminal back to normal when the player
+// presses Ctrl-C while it is in key mode.
+func restoreOnInterrupt(restore func() error) {
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	go func() {
+		<-interrupts
+		restore()
+		os.Exit(130)
+	}()
+}
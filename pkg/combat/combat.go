@@ -0,0 +1,116 @@
+package combat
+
+import (
+	"math/rand"
+
+	"bitcrawler/pkg/entity"
+)
+
+const (
+	// BaseHitChance is the percent chance to hit an equally matched foe
+	BaseHitChance = 75
+	// HitChancePerPoint is added for each point attack exceeds defense
+	HitChancePerPoint = 2
+	MinHitChance      = 5
+	MaxHitChance      = 95
+
+	// CritChance is the percent chance of a critical hit, which always lands
+	CritChance     = 5
+	CritMultiplier = 2
+
+	// DamageVariance is how far, in percent, damage strays from its average
+	DamageVariance = 25
+	// MinDamage is what a hit deals at least, unless fully resisted
+	MinDamage = 1
+)
+
+// Result describes the outcome of one attack for callers to report.
+type Result struct {
+	Hit      bool
+	Critical bool
+	Killed   bool
+	Damage   int
+	Resisted int
+	Type     entity.DamageType
+	// Inflicted lists the statuses the hit applied
+	Inflicted []entity.StatusKind
+}
+
+// HitChance is the percent chance for an attack to land against a defense.
+func HitChance(attack, defense int) int {
+	return clamp(BaseHitChance+(attack-defense)*HitChancePerPoint, MinHitChance, MaxHitChance)
+}
+
+// DamageRange returns the lowest and highest damage of a normal hit.
+func DamageRange(attack, defense int) (int, int) {
+	base := max(attack-defense, MinDamage)
+	spread := base * DamageVariance / 100
+	return max(base-spread, MinDamage), base + spread
+}
+
+// Resist reduces damage by a resistance percentage. Partly resisted damage
+// never drops below MinDamage, a resistance of 100 or more blocks it all and
+// a negative resistance is a weakness that increases it.
+func Resist(damage, resistance int) int {
+	if resistance >= 100 {
+		return 0
+	}
+	return max(damage-damage*resistance/100, MinDamage)
+}
+
+// Resolve rolls an attack and applies the damage to the defender.
+func Resolve(rng *rand.Rand, attacker, defender *entity.Character) Result {
+	attack := attacker.Attack + attacker.AttackBonus()
+	defense := defender.Defense + defender.DefenseBonus()
+	result := Result{Type: attacker.AttackDamageType()}
+
+	roll := rng.Intn(100)
+	result.Critical = roll < CritChance
+	result.Hit = result.Critical || roll < HitChance(attack, defense)
+	if !result.Hit {
+		return result
+	}
+
+	applyDamage(rng, &result, attack, defense, defender)
+	if result.Killed {
+		return result
+	}
+
+	for _, effect := range attacker.AttackEffects() {
+		if effect.Chance > 0 && rng.Intn(100) >= effect.Chance {
+			continue
+		}
+		defender.ApplyEffect(effect)
+		result.Inflicted = append(result.Inflicted, effect.Status)
+	}
+	return result
+}
+
+// Blast resolves an ability hit. It cannot miss and only half the
+// defender's defense counts against its power.
+func Blast(rng *rand.Rand, power int, damageType entity.DamageType, defender *entity.Character) Result {
+	defense := (defender.Defense + defender.DefenseBonus()) / 2
+	result := Result{Hit: true, Critical: rng.Intn(100) < CritChance, Type: damageType}
+	applyDamage(rng, &result, power, defense, defender)
+	return result
+}
+
+// applyDamage rolls the damage of a landed hit, doubles it on a critical,
+// applies the defender's resistance and takes it off their HP.
+func applyDamage(rng *rand.Rand, result *Result, attack, defense int, defender *entity.Character) {
+	low, high := DamageRange(attack, defense)
+	damage := low + rng.Intn(high-low+1)
+	if result.Critical {
+		damage *= CritMultiplier
+	}
+
+	result.Damage = Resist(damage, defender.Resistance(result.Type))
+	result.Resisted = max(damage-result.Damage, 0)
+
+	defender.HP = max(defender.HP-result.Damage, 0)
+	result.Killed = defender.HP == 0
+}
+
+func clamp(v, lo, hi int) int {
+	return min(max(v, lo), hi)
+}
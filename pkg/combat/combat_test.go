@@ -0,0 +1,169 @@
+package combat
+
+import (
+	"math/rand"
+	"testing"
+
+	"bitcrawler/pkg/entity"
+)
+
+func TestHitChance(t *testing.T) {
+	tests := []struct {
+		name            string
+		attack, defense int
+		want            int
+	}{
+		{"even", 10, 10, BaseHitChance},
+		{"stronger", 10, 5, BaseHitChance + 5*HitChancePerPoint},
+		{"weaker", 5, 10, BaseHitChance - 5*HitChancePerPoint},
+		{"clamped low", 0, 100, MinHitChance},
+		{"clamped high", 100, 0, MaxHitChance},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HitChance(tt.attack, tt.defense); got != tt.want {
+				t.Errorf("HitChance(%d, %d) = %d, want %d", tt.attack, tt.defense, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDamageRange(t *testing.T) {
+	tests := []struct {
+		name            string
+		attack, defense int
+		low, high       int
+	}{
+		{"attack below defense", 2, 30, MinDamage, MinDamage},
+		{"attack equals defense", 10, 10, MinDamage, MinDamage},
+		{"no spread", 3, 0, 3, 3},
+		{"spread", 15, 4, 9, 13},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			low, high := DamageRange(tt.attack, tt.defense)
+			if low != tt.low || high != tt.high {
+				t.Errorf("DamageRange(%d, %d) = %d, %d, want %d, %d", tt.attack, tt.defense, low, high, tt.low, tt.high)
+			}
+		})
+	}
+}
+
+func TestCriticalDoublesDamage(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	attacker := &entity.Character{Attack: 3}
+
+	crits := 0
+	for i := 0; i < 1000; i++ {
+		defender := &entity.Character{HP: 20}
+		result := Resolve(rng, attacker, defender)
+		if !result.Hit {
+			continue
+		}
+
+		want := 3
+		if result.Critical {
+			want *= CritMultiplier
+			crits++
+		}
+		if result.Damage != want {
+			t.Fatalf("damage = %d, want %d (critical %v)", result.Damage, want, result.Critical)
+		}
+	}
+	if crits == 0 {
+		t.Fatal("no critical hits in 1000 attacks")
+	}
+}
+
+func TestResist(t *testing.T) {
+	tests := []struct {
+		name               string
+		damage, resistance int
+		want               int
+	}{
+		{"none", 10, 0, 10},
+		{"half", 10, 50, 5},
+		{"half keeps minimum", 1, 50, MinDamage},
+		{"immune", 10, 100, 0},
+		{"beyond immune", 10, 150, 0},
+		{"weakness", 10, -50, 15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Resist(tt.damage, tt.resistance); got != tt.want {
+				t.Errorf("Resist(%d, %d) = %d, want %d", tt.damage, tt.resistance, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		attacker entity.Character
+		defender entity.Character
+		maxHit   int
+	}{
+		{
+			name:     "overwhelming attack kills",
+			attacker: entity.Character{Attack: 100},
+			defender: entity.Character{HP: 5, MaxHP: 5},
+		},
+		{
+			name:     "hopeless attack never heals",
+			attacker: entity.Character{Attack: 0},
+			defender: entity.Character{HP: 30, MaxHP: 30, Defense: 50},
+			maxHit:   MinDamage * CritMultiplier,
+		},
+		{
+			name:     "armor adds to defense",
+			attacker: entity.Character{Attack: 20},
+			defender: entity.Character{HP: 200, MaxHP: 200, Equipment: map[entity.EquipSlot]*entity.Item{
+				entity.SlotArmor: {Effect: entity.Effect{Defense: 10}},
+			}},
+			maxHit: 12 * CritMultiplier,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(42))
+			attacker, defender := tt.attacker, tt.defender
+
+			for i := 0; i < 100 && defender.HP > 0; i++ {
+				before := defender.HP
+				result := Resolve(rng, &attacker, &defender)
+
+				if defender.HP < 0 {
+					t.Fatalf("HP = %d, want it floored at 0", defender.HP)
+				}
+				if defender.HP > before {
+					t.Fatalf("HP went from %d to %d, an attack must never heal", before, defender.HP)
+				}
+				if result.Hit && result.Damage < MinDamage {
+					t.Errorf("hit dealt %d damage, want at least %d", result.Damage, MinDamage)
+				}
+				if !result.Hit && result.Damage != 0 {
+					t.Errorf("miss dealt %d damage", result.Damage)
+				}
+				if tt.maxHit > 0 && result.Damage > tt.maxHit {
+					t.Errorf("hit dealt %d damage, want at most %d", result.Damage, tt.maxHit)
+				}
+				if result.Killed != (defender.HP == 0) {
+					t.Errorf("Killed = %v with %d HP left", result.Killed, defender.HP)
+				}
+			}
+		})
+	}
+
+	t.Run("kill floors HP at zero", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(42))
+		attacker := &entity.Character{Attack: 100}
+		defender := &entity.Character{HP: 1, MaxHP: 1}
+		for i := 0; i < 100 && defender.HP > 0; i++ {
+			Resolve(rng, attacker, defender)
+		}
+		if defender.HP != 0 {
+			t.Errorf("HP = %d, want 0", defender.HP)
+		}
+	})
+}
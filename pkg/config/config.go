@@ -0,0 +1,249 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/generator"
+	"bitcrawler/pkg/input"
+	"bitcrawler/pkg/logging"
+	"bitcrawler/pkg/pathfinding"
+	"bitcrawler/pkg/render"
+)
+
+const (
+	maxMapSize      = 1000
+	maxEnemyDensity = 10
+
+	DefaultFinalLevel = 5
+)
+
+const (
+	DifficultyEasy   = "easy"
+	DifficultyNormal = "normal"
+	DifficultyHard   = "hard"
+)
+
+var Difficulties = []string{DifficultyEasy, DifficultyNormal, DifficultyHard}
+
+type Config struct {
+	Seed         int64
+	Width        int
+	Height       int
+	Algorithm    string
+	FinalLevel   int
+	EnemyDensity float64
+	Difficulty   string
+	LogLevel     string
+	LogFile      string
+	Replay       string
+	Renderer     string
+	Input        string
+	KeyBindings  map[string]string
+	Pathfinding  pathfinding.Options
+	Player       PlayerConfig
+}
+
+type PlayerConfig struct {
+	Name              string
+	HP                int
+	Attack            int
+	Defense           int
+	Mana              int
+	Stamina           int
+	InventoryCapacity int
+}
+
+func Default() Config {
+	return Config{
+		Width:        40,
+		Height:       16,
+		Algorithm:    generator.DefaultAlgorithm,
+		FinalLevel:   DefaultFinalLevel,
+		EnemyDensity: 1,
+		Difficulty:   DifficultyNormal,
+		LogLevel:     "debug",
+		LogFile:      logging.DefaultLogFile,
+		Renderer:     render.RendererANSI,
+		Input:        input.ModeText,
+		Pathfinding: pathfinding.Options{
+			StraightCost: pathfinding.DefaultStraightCost,
+			DiagonalCost: pathfinding.DefaultDiagonalCost,
+			MaxNodes:     pathfinding.DefaultMaxNodes,
+		},
+		Player: PlayerConfig{
+			Name:              "Hero",
+			HP:                100,
+			Attack:            10,
+			Defense:           5,
+			Mana:              20,
+			Stamina:           20,
+			InventoryCapacity: entity.DefaultInventoryCapacity,
+		},
+	}
+}
+
+// Load reads a JSON config file on top of the defaults, so a file only
+// needs to contain the settings it changes.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+func bindFlags(fs *flag.FlagSet, c *Config) *string {
+	path := fs.String("config", "", "path to a JSON config file")
+	fs.Int64Var(&c.Seed, "seed", c.Seed, "seed for the dungeon, 0 picks a random one")
+	fs.IntVar(&c.Width, "width", c.Width, "map width")
+	fs.IntVar(&c.Height, "height", c.Height, "map height")
+	fs.StringVar(&c.Algorithm, "algorithm", c.Algorithm,
+		"map generator ("+strings.Join(generator.Algorithms(), ", ")+")")
+	fs.IntVar(&c.FinalLevel, "final-level", c.FinalLevel, "level to escape from, 0 for endless")
+	fs.Float64Var(&c.EnemyDensity, "enemy-density", c.EnemyDensity, "multiplier on the number of enemy packs")
+	fs.StringVar(&c.Difficulty, "difficulty", c.Difficulty,
+		"enemy difficulty ("+strings.Join(Difficulties, ", ")+")")
+	fs.StringVar(&c.LogLevel, "log-level", c.LogLevel, "log level (error, warning, info, debug)")
+	fs.StringVar(&c.LogFile, "log-file", c.LogFile, "log destination, a path or stderr")
+	fs.StringVar(&c.Replay, "replay", c.Replay, "play the commands in a file instead of reading the terminal")
+	fs.StringVar(&c.Renderer, "renderer", c.Renderer,
+		"output mode ("+strings.Join(render.Renderers, ", ")+")")
+	fs.StringVar(&c.Input, "input", c.Input,
+		"input mode ("+strings.Join(input.Modes, ", ")+"), keys falls back to text without a terminal")
+	fs.IntVar(&c.Pathfinding.StraightCost, "path-straight-cost", c.Pathfinding.StraightCost, "enemy pathfinding cost of a straight step")
+	fs.IntVar(&c.Pathfinding.DiagonalCost, "path-diagonal-cost", c.Pathfinding.DiagonalCost, "enemy pathfinding cost of a diagonal step")
+	fs.IntVar(&c.Pathfinding.MaxNodes, "path-budget", c.Pathfinding.MaxNodes, "tiles an enemy searches before giving up on a path")
+	fs.StringVar(&c.Player.Name, "name", c.Player.Name, "player name")
+	fs.IntVar(&c.Player.HP, "hp", c.Player.HP, "player starting HP")
+	fs.IntVar(&c.Player.Attack, "attack", c.Player.Attack, "player starting attack")
+	fs.IntVar(&c.Player.Defense, "defense", c.Player.Defense, "player starting defense")
+	fs.IntVar(&c.Player.Mana, "mana", c.Player.Mana, "player starting mana")
+	fs.IntVar(&c.Player.Stamina, "stamina", c.Player.Stamina, "player starting stamina")
+	fs.IntVar(&c.Player.InventoryCapacity, "inventory", c.Player.InventoryCapacity, "player inventory capacity")
+	return path
+}
+
+// Parse builds the config from command line arguments. Settings from a
+// config file are applied first and flags given on the command line
+// override them.
+func Parse(name string, args []string) (Config, error) {
+	// first pass only finds the config file
+	probe := Default()
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	path := bindFlags(fs, &probe)
+	if err := fs.Parse(args); err != nil {
+		return probe, err
+	}
+
+	cfg := Default()
+	if *path != "" {
+		var err error
+		if cfg, err = Load(*path); err != nil {
+			return cfg, err
+		}
+	}
+
+	// second pass lets explicit flags win over the file
+	fs = flag.NewFlagSet(name, flag.ContinueOnError)
+	bindFlags(fs, &cfg)
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	return cfg, cfg.Validate()
+}
+
+func (c Config) Validate() error {
+	var errs []error
+
+	if !slices.Contains(generator.Algorithms(), c.Algorithm) {
+		errs = append(errs, fmt.Errorf("unknown algorithm %q", c.Algorithm))
+	} else if err := generator.CheckSize(c.Algorithm, c.Width, c.Height); err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.Width > maxMapSize || c.Height > maxMapSize {
+		errs = append(errs, fmt.Errorf("map cannot be larger than %d x %d", maxMapSize, maxMapSize))
+	}
+
+	if c.FinalLevel < 0 {
+		errs = append(errs, fmt.Errorf("final level cannot be negative"))
+	}
+
+	if c.EnemyDensity < 0 || c.EnemyDensity > maxEnemyDensity {
+		errs = append(errs, fmt.Errorf("enemy density must be between 0 and %d", maxEnemyDensity))
+	}
+
+	if !slices.Contains(Difficulties, c.Difficulty) {
+		errs = append(errs, fmt.Errorf("unknown difficulty %q", c.Difficulty))
+	}
+
+	if _, err := logging.ParseLogLevel(c.LogLevel); err != nil {
+		errs = append(errs, err)
+	}
+
+	if !slices.Contains(render.Renderers, c.Renderer) {
+		errs = append(errs, fmt.Errorf("unknown renderer %q", c.Renderer))
+	}
+
+	if !slices.Contains(input.Modes, c.Input) {
+		errs = append(errs, fmt.Errorf("unknown input mode %q", c.Input))
+	}
+
+	for key, command := range c.KeyBindings {
+		if key == "" || key == input.KeyCommandLine {
+			errs = append(errs, fmt.Errorf("cannot bind key %q", key))
+		}
+		if strings.TrimSpace(command) == "" {
+			errs = append(errs, fmt.Errorf("key %q is bound to an empty command", key))
+		}
+	}
+
+	if c.Pathfinding.StraightCost <= 0 || c.Pathfinding.DiagonalCost <= 0 {
+		errs = append(errs, fmt.Errorf("pathfinding step costs must be positive"))
+	}
+
+	if c.Pathfinding.MaxNodes <= 0 {
+		errs = append(errs, fmt.Errorf("pathfinding budget must be positive"))
+	}
+
+	if c.LogFile == "" {
+		errs = append(errs, fmt.Errorf("log file cannot be empty"))
+	}
+
+	if c.Player.Name == "" {
+		errs = append(errs, fmt.Errorf("player name cannot be empty"))
+	}
+
+	if c.Player.HP <= 0 {
+		errs = append(errs, fmt.Errorf("player HP must be positive"))
+	}
+
+	if c.Player.Attack < 0 || c.Player.Defense < 0 {
+		errs = append(errs, fmt.Errorf("player attack and defense cannot be negative"))
+	}
+
+	if c.Player.Mana < 0 || c.Player.Stamina < 0 {
+		errs = append(errs, fmt.Errorf("player mana and stamina cannot be negative"))
+	}
+
+	if c.Player.InventoryCapacity < 0 {
+		errs = append(errs, fmt.Errorf("inventory capacity cannot be negative"))
+	}
+
+	return errors.Join(errs...)
+}
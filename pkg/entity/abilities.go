@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+type AbilityShape string
+
+const (
+	ShapeSelf  AbilityShape = "self"
+	ShapeBolt  AbilityShape = "bolt"
+	ShapeCone  AbilityShape = "cone"
+	ShapeBurst AbilityShape = "burst"
+)
+
+type Resource string
+
+const (
+	ResourceMana    Resource = "mana"
+	ResourceStamina Resource = "stamina"
+)
+
+// IsActive reports whether the ability is cast rather than always on.
+func (a Ability) IsActive() bool {
+	return a.Shape != ""
+}
+
+// FindAbility looks up an ability by its name or part of it, preferring
+// active abilities over passive ones.
+func (c *Character) FindAbility(name string) *Ability {
+	if ability := c.findAbility(name, true); ability != nil {
+		return ability
+	}
+	return c.findAbility(name, false)
+}
+
+func (c *Character) findAbility(name string, active bool) *Ability {
+	for i, ability := range c.Abilities {
+		if ability.IsActive() == active && strings.EqualFold(ability.Name, name) {
+			return &c.Abilities[i]
+		}
+	}
+	for i, ability := range c.Abilities {
+		if ability.IsActive() == active && MatchesName(ability.Name, name) {
+			return &c.Abilities[i]
+		}
+	}
+	return nil
+}
+
+// AbilityReady returns why the ability cannot be cast right now, if anything.
+func (c *Character) AbilityReady(ability *Ability) error {
+	if turns := c.Cooldowns[ability.Name]; turns > 0 {
+		return fmt.Errorf("%s is not ready for another %d turns", ability.Name, turns)
+	}
+	if pool := c.resource(ability.Resource); pool != nil && *pool < ability.Cost {
+		return fmt.Errorf("not enough %s for %s", ability.Resource, ability.Name)
+	}
+	return nil
+}
+
+// SpendFor pays the cost of an ability and starts its cooldown.
+func (c *Character) SpendFor(ability *Ability) {
+	if pool := c.resource(ability.Resource); pool != nil {
+		*pool -= ability.Cost
+	}
+	if ability.Cooldown > 0 {
+		if c.Cooldowns == nil {
+			c.Cooldowns = make(map[string]int)
+		}
+		c.Cooldowns[ability.Name] = ability.Cooldown
+	}
+}
+
+// TickCooldowns brings every ability a turn closer to being ready.
+func (c *Character) TickCooldowns() {
+	for name, turns := range c.Cooldowns {
+		if turns <= 1 {
+			delete(c.Cooldowns, name)
+		} else {
+			c.Cooldowns[name] = turns - 1
+		}
+	}
+}
+
+// Recover refills the resource pools up to their maximum.
+func (c *Character) Recover(resource Resource, amount int) {
+	pool := c.resource(resource)
+	if pool == nil {
+		return
+	}
+
+	limit := c.MaxMana
+	if resource == ResourceStamina {
+		limit = c.MaxStamina
+	}
+	*pool = min(*pool+amount, limit)
+}
+
+func (c *Character) resource(resource Resource) *int {
+	switch resource {
+	case ResourceMana:
+		return &c.Mana
+	case ResourceStamina:
+		return &c.Stamina
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package entity
+
+type DamageType string
+
+const (
+	DamagePhysical DamageType = "physical"
+	DamageFire     DamageType = "fire"
+	DamageCold     DamageType = "cold"
+	DamagePoison   DamageType = "poison"
+)
+
+// AttackDamageType is the damage type a character deals, taken from the
+// equipped weapon first and defaulting to physical.
+func (c *Character) AttackDamageType() DamageType {
+	if weapon := c.Equipment[SlotWeapon]; weapon != nil && weapon.DamageType != "" {
+		return weapon.DamageType
+	}
+	if c.DamageType != "" {
+		return c.DamageType
+	}
+	return DamagePhysical
+}
+
+// Resistance is the percentage of damage of a type the character ignores.
+func (c *Character) Resistance(damageType DamageType) int {
+	return c.Resistances[damageType]
+}
@@ -1,41 +1,100 @@
 package entity
 
+import "fmt"
+
 type Character struct {
-	ID            ID
-	Name          string
-	HP            int
-	Attack        int
-	Defense       int
-	Abilities     []Ability
-	Visual        rune
-	PreHook       func(*Character)
-	PostHook      func(*Character)
-	PreviousX     int
-	PreviousY     int
-	X             int
-	Y             int
-	Description   string
-	HealthyText   string
-	DamagedText   string
-	WoundedText   string
-	DeadText      string
-	DeathMessage  string
-	SeenMessage   string
-	BattleMessage string
-	HasDied       bool
-	HasExited     bool
+	ID                ID
+	Name              string
+	HP                int
+	MaxHP             int
+	Attack            int
+	Defense           int
+	Speed             int
+	Energy            int
+	Abilities         []Ability
+	Inventory         []*Item
+	InventoryCapacity int
+	Equipment         map[EquipSlot]*Item
+	Visual            rune
+	PreHook           func(*Character) `json:"-"`
+	PostHook          func(*Character) `json:"-"`
+	PreviousX         int
+	PreviousY         int
+	X                 int
+	Y                 int
+	Description       string
+	HealthyText       string
+	DamagedText       string
+	WoundedText       string
+	DeadText          string
+	DeathMessage      string
+	SeenMessage       string
+	BattleMessage     string
+	HasDied           bool
+	HasBeenSeen       bool
+	HasExited         bool
+
+	// damage dealt and percentage resisted per damage type
+	DamageType  DamageType
+	Resistances map[DamageType]int
+
+	// Effects are the active timed effects, Inflicts are applied on a hit
+	Effects  []Effect
+	Inflicts []Effect
+
+	// resources spent on active abilities and turns until each is ready
+	Mana       int
+	MaxMana    int
+	Stamina    int
+	MaxStamina int
+	Cooldowns  map[string]int
+
+	// AI settings, see the Behavior constants
+	Behavior       string
+	FleeThreshold  int
+	Range          int
+	PreferredRange int
+	GuardRadius    int
+	HomeX          int
+	HomeY          int
+
+	// perception and pack state, see the AIState constants
+	AIState   string
+	LastSeenX int
+	LastSeenY int
+	FleeTurns int
+	PackID    int
+	IsLeader  bool
 }
 
 type Ability struct {
 	Name        string
 	Description string
 	Effect      Effect
+
+	// active abilities are cast in a shape and apply Effect to whatever
+	// they hit, passive ones always add their Effect to the character
+	Shape      AbilityShape `json:",omitempty"`
+	Range      int          `json:",omitempty"`
+	Power      int          `json:",omitempty"`
+	DamageType DamageType   `json:",omitempty"`
+	Resource   Resource     `json:",omitempty"`
+	Cost       int          `json:",omitempty"`
+	Cooldown   int          `json:",omitempty"`
 }
 
 type Effect struct {
 	Attack  int
 	Defense int
 	HP      int
+	Speed   int `json:",omitempty"`
+
+	// timed status effects apply HP every turn until Duration runs out,
+	// Chance is the percent chance to apply one on a hit
+	Status   StatusKind `json:",omitempty"`
+	Duration int        `json:",omitempty"`
+	Stacks   int        `json:",omitempty"`
+	Chance   int        `json:",omitempty"`
 }
 
 type ID uint8
@@ -46,4 +105,63 @@ const (
 	ObjEnemy
 	ObjWall
 	ObjExit
+	ObjItem
 )
+
+// NormalSpeed is the energy a character gains per tick of the game clock
+const NormalSpeed = 100
+
+// EffectiveSpeed returns the character speed, treating an unset speed as
+// normal.
+func (c *Character) EffectiveSpeed() int {
+	speed := c.Speed
+	if speed <= 0 {
+		speed = NormalSpeed
+	}
+	for _, effect := range c.Effects {
+		speed += effect.Speed * effect.Stacks
+	}
+	return max(speed, 1)
+}
+
+// HealthDescription describes how hurt the character looks.
+func (c *Character) HealthDescription() string {
+	if c.HP <= 0 {
+		return "dead"
+	}
+	if c.MaxHP <= 0 {
+		return "unhurt"
+	}
+
+	switch ratio := c.HP * 100 / c.MaxHP; {
+	case ratio >= 100:
+		return "unhurt"
+	case ratio >= 70:
+		return "lightly wounded"
+	case ratio >= 35:
+		return "wounded"
+	default:
+		return "near death"
+	}
+}
+
+// HealthText is the flavour text matching how hurt the character is,
+// falling back to a plain description when the character has none.
+func (c *Character) HealthText() string {
+	var text string
+	switch c.HealthDescription() {
+	case "dead":
+		text = c.DeadText
+	case "unhurt", "lightly wounded":
+		text = c.HealthyText
+	case "wounded":
+		text = c.DamagedText
+	default:
+		text = c.WoundedText
+	}
+
+	if text == "" {
+		return fmt.Sprintf("The %s looks %s.", c.Name, c.HealthDescription())
+	}
+	return text
+}
@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type EquipSlot string
+
+const (
+	SlotNone   EquipSlot = ""
+	SlotWeapon EquipSlot = "weapon"
+	SlotArmor  EquipSlot = "armor"
+	SlotShield EquipSlot = "shield"
+)
+
+var EquipSlots = []EquipSlot{SlotWeapon, SlotArmor, SlotShield}
+
+// Equip moves an item from the inventory into its equipment slot, swapping
+// out whatever was equipped there before.
+func (c *Character) Equip(name string) (*Item, error) {
+	item := c.FindItem(name)
+	if item == nil {
+		return nil, fmt.Errorf("you are not carrying %s", name)
+	}
+
+	if item.Slot == SlotNone {
+		return nil, fmt.Errorf("you cannot equip the %s", item.Name)
+	}
+
+	if _, err := c.RemoveItem(name); err != nil {
+		return nil, err
+	}
+
+	if c.Equipment == nil {
+		c.Equipment = make(map[EquipSlot]*Item)
+	}
+
+	// the previously equipped item takes the freed inventory space
+	if previous, ok := c.Equipment[item.Slot]; ok {
+		c.Inventory = append(c.Inventory, previous)
+	}
+	c.Equipment[item.Slot] = item
+
+	return item, nil
+}
+
+// Unequip moves an equipped item back into the inventory. The item can be
+// named either by its slot or by its name.
+func (c *Character) Unequip(name string) (*Item, error) {
+	for _, slot := range EquipSlots {
+		item, ok := c.Equipment[slot]
+		if !ok {
+			continue
+		}
+
+		if strings.ToLower(name) != string(slot) && !MatchesItem(item, name) {
+			continue
+		}
+
+		if err := c.AddItem(item); err != nil {
+			return nil, err
+		}
+		delete(c.Equipment, slot)
+
+		return item, nil
+	}
+
+	return nil, fmt.Errorf("you have no %s equipped", name)
+}
+
+// AttackBonus sums the attack modifiers from passive abilities, equipped
+// gear and active effects.
+func (c *Character) AttackBonus() int {
+	var bonus int
+	for _, ability := range c.Abilities {
+		if !ability.IsActive() {
+			bonus += ability.Effect.Attack
+		}
+	}
+	for _, item := range c.Equipment {
+		bonus += item.Effect.Attack
+	}
+	for _, effect := range c.Effects {
+		bonus += effect.Attack * effect.Stacks
+	}
+	return bonus
+}
+
+// DefenseBonus sums the defense modifiers from passive abilities, equipped
+// gear and active effects.
+func (c *Character) DefenseBonus() int {
+	var bonus int
+	for _, ability := range c.Abilities {
+		if !ability.IsActive() {
+			bonus += ability.Effect.Defense
+		}
+	}
+	for _, item := range c.Equipment {
+		bonus += item.Effect.Defense
+	}
+	for _, effect := range c.Effects {
+		bonus += effect.Defense * effect.Stacks
+	}
+	return bonus
+}
+
+// AttackEffects lists the effects the character may inflict on a hit.
+func (c *Character) AttackEffects() []Effect {
+	effects := c.Inflicts
+	if weapon := c.Equipment[SlotWeapon]; weapon != nil {
+		effects = append(slices.Clone(effects), weapon.Inflicts...)
+	}
+	return effects
+}
@@ -1,5 +1,25 @@
 package entity
 
+import (
+	"maps"
+	"slices"
+)
+
+const (
+	BehaviorChase  = "chase"
+	BehaviorFlee   = "flee"
+	BehaviorWander = "wander"
+	BehaviorGuard  = "guard"
+	BehaviorRanged = "ranged"
+)
+
+const (
+	AIStateIdle    = "idle"
+	AIStateAlerted = "alerted"
+	AIStateHunting = "hunting"
+	AIStateFleeing = "fleeing"
+)
+
 var (
 	GoblinEnemyTemplate = Character{
 		ID:      ObjEnemy,
@@ -8,6 +28,18 @@ var (
 		Attack:  10,
 		Defense: 2,
 		Visual:  'g',
+
+		Speed:         50,
+		Behavior:      BehaviorFlee,
+		FleeThreshold: 25,
+
+		SeenMessage: "A goblin spots you and bares its yellow teeth!",
+
+		Description: "A wiry, green-skinned creature with a crooked blade and darting eyes.",
+		HealthyText: "It hops from foot to foot, eager for a fight.",
+		DamagedText: "It bleeds from a few shallow cuts and snarls at you.",
+		WoundedText: "It clutches a deep wound and glances around for a way out.",
+		DeadText:    "The goblin lies crumpled on the floor, its blade still in hand.",
 	}
 	GoblinLeaderTemplate = Character{
 		ID:      ObjEnemy,
@@ -16,6 +48,156 @@ var (
 		Attack:  15,
 		Defense: 5,
 		Visual:  'G',
+
+		Speed:    50,
+		Behavior: BehaviorChase,
+
+		Inflicts: []Effect{
+			{HP: -1, Status: StatusBleeding, Duration: 4, Chance: 25},
+		},
+
+		SeenMessage: "A hulking goblin leader barks orders at its pack!",
+
+		Description: "A broad-shouldered goblin in scavenged mail, scarred from many raids.",
+		HealthyText: "It stands tall and bellows at its pack.",
+		DamagedText: "Blood runs down its mail, but it only grins wider.",
+		WoundedText: "It staggers and its orders have turned into ragged gasps.",
+		DeadText:    "The goblin leader lies sprawled across the stones, its pack leaderless.",
+	}
+	GoblinArcherTemplate = Character{
+		ID:      ObjEnemy,
+		Name:    "Goblin Archer",
+		HP:      20,
+		Attack:  7,
+		Defense: 1,
+		Visual:  'a',
+
+		Speed:          50,
+		Behavior:       BehaviorRanged,
+		Range:          5,
+		PreferredRange: 3,
+
+		Inflicts: []Effect{
+			{Attack: -3, Status: StatusWeakness, Duration: 6, Chance: 20},
+		},
+		Stamina:    10,
+		MaxStamina: 10,
+		Abilities: []Ability{{
+			Name:        "Poison Arrow",
+			Description: "An arrow dipped in something foul",
+			Shape:       ShapeBolt,
+			Range:       5,
+			Power:       5,
+			DamageType:  DamagePoison,
+			Resource:    ResourceStamina,
+			Cost:        5,
+			Cooldown:    5,
+			Effect:      Effect{HP: -1, Status: StatusPoison, Duration: 4},
+		}},
+
+		SeenMessage: "A goblin archer nocks an arrow and takes aim!",
+
+		Description: "A lean goblin with a short bow and a quiver of crude arrows.",
+		HealthyText: "It keeps its distance, an arrow always on the string.",
+		DamagedText: "Its hands shake as it fumbles for another arrow.",
+		WoundedText: "It limps badly, its bowstring hanging slack.",
+		DeadText:    "The goblin archer lies still amid its scattered arrows.",
+	}
+	GoblinSentryTemplate = Character{
+		ID:      ObjEnemy,
+		Name:    "Goblin Sentry",
+		HP:      40,
+		Attack:  9,
+		Defense: 4,
+		Visual:  's',
+
+		Speed:       50,
+		Behavior:    BehaviorGuard,
+		GuardRadius: 4,
+
+		Resistances: map[DamageType]int{DamagePhysical: 20},
+		Inflicts: []Effect{
+			{Status: StatusStun, Duration: 2, Chance: 15},
+		},
+
+		SeenMessage: "A goblin sentry raises its spear and holds its ground.",
+
+		Description: "A stocky goblin with a long spear and a battered helmet, set to keep watch.",
+		HealthyText: "It plants its spear and watches you without blinking.",
+		DamagedText: "It leans on its spear, dented helmet askew.",
+		WoundedText: "It can barely lift its spear any more.",
+		DeadText:    "The goblin sentry has fallen at its post.",
+	}
+	GoblinShamanTemplate = Character{
+		ID:      ObjEnemy,
+		Name:    "Goblin Shaman",
+		HP:      18,
+		Attack:  5,
+		Defense: 1,
+		Visual:  'h',
+
+		Speed:          50,
+		Behavior:       BehaviorRanged,
+		FleeThreshold:  50,
+		PreferredRange: 3,
+
+		Mana:    16,
+		MaxMana: 16,
+		Abilities: []Ability{
+			{
+				Name:        "Spark",
+				Description: "A crackling bolt of fire",
+				Shape:       ShapeBolt,
+				Range:       5,
+				Power:       8,
+				DamageType:  DamageFire,
+				Resource:    ResourceMana,
+				Cost:        4,
+				Cooldown:    3,
+			},
+			{
+				Name:        "Mend",
+				Description: "A muttered charm that closes wounds",
+				Shape:       ShapeSelf,
+				Resource:    ResourceMana,
+				Cost:        6,
+				Cooldown:    12,
+				Effect:      Effect{HP: 2, Status: StatusRegeneration, Duration: 6},
+			},
+		},
+
+		SeenMessage: "A goblin shaman rattles a string of bones and begins to chant!",
+
+		Description: "A hunched goblin draped in feathers and bones, its fingers crackling with sparks.",
+		HealthyText: "It chants in a high, grating voice.",
+		DamagedText: "Its chanting falters as it clutches its side.",
+		WoundedText: "It stumbles, its charms scattered and broken.",
+		DeadText:    "The goblin shaman lies among its scattered bones.",
+	}
+	CaveRatTemplate = Character{
+		ID:      ObjEnemy,
+		Name:    "Cave Rat",
+		HP:      8,
+		Attack:  4,
+		Defense: 0,
+		Visual:  'r',
+
+		Speed:    120,
+		Behavior: BehaviorWander,
+
+		DamageType:  DamagePoison,
+		Resistances: map[DamageType]int{DamagePoison: 50},
+		Inflicts: []Effect{
+			{HP: -1, Status: StatusPoison, Duration: 5, Chance: 40},
+		},
+
+		SeenMessage: "A cave rat scurries through the dust.",
+
+		Description: "A mangy rat the size of a cat, all teeth and whiskers.",
+		HealthyText: "It sniffs the air and chitters.",
+		DamagedText: "It squeals and bares its teeth.",
+		WoundedText: "It drags itself along, one leg limp.",
+		DeadText:    "The cave rat lies on its back, legs in the air.",
 	}
 )
 
@@ -24,8 +206,48 @@ func NewEnemy(character Character) *Character {
 		Name:    character.Name,
 		ID:      character.ID,
 		HP:      character.HP,
+		MaxHP:   character.HP,
 		Attack:  character.Attack,
 		Defense: character.Defense,
+		Speed:   character.Speed,
 		Visual:  character.Visual,
+
+		Description:   character.Description,
+		HealthyText:   character.HealthyText,
+		DamagedText:   character.DamagedText,
+		WoundedText:   character.WoundedText,
+		DeadText:      character.DeadText,
+		DeathMessage:  character.DeathMessage,
+		SeenMessage:   character.SeenMessage,
+		BattleMessage: character.BattleMessage,
+
+		Behavior:       character.Behavior,
+		FleeThreshold:  character.FleeThreshold,
+		Range:          character.Range,
+		PreferredRange: character.PreferredRange,
+		GuardRadius:    character.GuardRadius,
+
+		DamageType:  character.DamageType,
+		Resistances: maps.Clone(character.Resistances),
+		Inflicts:    slices.Clone(character.Inflicts),
+
+		Abilities:  slices.Clone(character.Abilities),
+		Mana:       character.Mana,
+		MaxMana:    character.MaxMana,
+		Stamina:    character.Stamina,
+		MaxStamina: character.MaxStamina,
 	}
 }
+
+// ScaleToLevel strengthens an enemy for the dungeon level it spawns on.
+func ScaleToLevel(character *Character, level int) {
+	if level <= 1 {
+		return
+	}
+
+	depth := level - 1
+	character.HP += character.HP * depth / 4
+	character.MaxHP = character.HP
+	character.Attack += depth
+	character.Defense += depth / 2
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const DefaultInventoryCapacity = 10
+
+var ErrInventoryFull = errors.New("your inventory is full")
+
+// MatchesName reports whether a name matches what the player typed.
+func MatchesName(name, typed string) bool {
+	return strings.Contains(strings.ToLower(name), strings.ToLower(typed))
+}
+
+// MatchesItem reports whether an item name matches what the player typed.
+func MatchesItem(item *Item, name string) bool {
+	return MatchesName(item.Name, name)
+}
+
+func (c *Character) AddItem(item *Item) error {
+	if len(c.Inventory) >= c.InventoryCapacity {
+		return ErrInventoryFull
+	}
+	c.Inventory = append(c.Inventory, item)
+	return nil
+}
+
+func (c *Character) FindItem(name string) *Item {
+	for _, item := range c.Inventory {
+		if MatchesItem(item, name) {
+			return item
+		}
+	}
+	return nil
+}
+
+func (c *Character) RemoveItem(name string) (*Item, error) {
+	for i, item := range c.Inventory {
+		if MatchesItem(item, name) {
+			c.Inventory = append(c.Inventory[:i], c.Inventory[i+1:]...)
+			return item, nil
+		}
+	}
+	return nil, fmt.Errorf("you are not carrying %s", name)
+}
@@ -0,0 +1,29 @@
+package entity
+
+type ItemKind uint8
+
+const (
+	ItemTreasure ItemKind = iota
+	ItemWeapon
+	ItemArmor
+	ItemConsumable
+)
+
+type Item struct {
+	ID          ID
+	Name        string
+	Description string
+	Kind        ItemKind
+	Slot        EquipSlot
+	Visual      rune
+	Effect      Effect
+	DamageType  DamageType `json:",omitempty"`
+	Value       int        `json:",omitempty"`
+	Inflicts    []Effect   `json:",omitempty"`
+}
+
+func NewItem(item Item) *Item {
+	i := item
+	i.ID = ObjItem
+	return &i
+}
@@ -0,0 +1,116 @@
+package entity
+
+import "fmt"
+
+type StatusKind string
+
+const (
+	StatusPoison       StatusKind = "poison"
+	StatusRegeneration StatusKind = "regeneration"
+	StatusStun         StatusKind = "stun"
+	StatusHaste        StatusKind = "haste"
+	StatusWeakness     StatusKind = "weakness"
+	StatusBleeding     StatusKind = "bleeding"
+)
+
+var statusAdjectives = map[StatusKind]string{
+	StatusPoison:       "poisoned",
+	StatusRegeneration: "regenerating",
+	StatusStun:         "stunned",
+	StatusHaste:        "hasted",
+	StatusWeakness:     "weakened",
+	StatusBleeding:     "bleeding",
+}
+
+// Adjective describes a character under the status, e.g. "poisoned".
+func (s StatusKind) Adjective() string {
+	if adjective, ok := statusAdjectives[s]; ok {
+		return adjective
+	}
+	return string(s)
+}
+
+// StatusNames lists the active statuses, with stacks, e.g. "poisoned x2".
+func (c *Character) StatusNames() []string {
+	var names []string
+	for _, effect := range c.Effects {
+		name := effect.Status.Adjective()
+		if effect.Stacks > 1 {
+			name += fmt.Sprintf(" x%d", effect.Stacks)
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// statusStacks is how often a status stacks its strength. Applying a status
+// again beyond that, or one that does not stack, only refreshes its duration.
+var statusStacks = map[StatusKind]int{
+	StatusPoison:   5,
+	StatusBleeding: 3,
+}
+
+// ApplyEffect applies an effect to the character. Effects without a duration
+// change HP once, timed effects last until their duration runs out.
+func (c *Character) ApplyEffect(effect Effect) {
+	if effect.Duration <= 0 {
+		c.heal(effect.HP)
+		return
+	}
+
+	for i := range c.Effects {
+		active := &c.Effects[i]
+		if active.Status != effect.Status {
+			continue
+		}
+
+		active.Duration = max(active.Duration, effect.Duration)
+		if active.Stacks < max(statusStacks[effect.Status], 1) {
+			active.Stacks++
+		}
+		return
+	}
+
+	effect.Stacks = 1
+	effect.Chance = 0
+	c.Effects = append(c.Effects, effect)
+}
+
+// TickEffects runs one turn of the active effects. It returns the HP they
+// changed and the effects that wore off.
+func (c *Character) TickEffects() (int, []Effect) {
+	before := c.HP
+	var expired []Effect
+
+	active := c.Effects[:0]
+	for _, effect := range c.Effects {
+		c.heal(effect.HP * effect.Stacks)
+		effect.Duration--
+		if effect.Duration > 0 {
+			active = append(active, effect)
+		} else {
+			expired = append(expired, effect)
+		}
+	}
+	c.Effects = active
+
+	return c.HP - before, expired
+}
+
+// HasStatus reports whether the character is under a status.
+func (c *Character) HasStatus(status StatusKind) bool {
+	for _, effect := range c.Effects {
+		if effect.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// heal changes HP without going below zero or above MaxHP.
+func (c *Character) heal(hp int) {
+	c.HP = max(c.HP+hp, 0)
+	if c.MaxHP > 0 {
+		c.HP = min(c.HP, c.MaxHP)
+	}
+}
@@ -0,0 +1,211 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"bitcrawler/pkg/combat"
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/logging"
+	"bitcrawler/pkg/room"
+)
+
+const (
+	// turns it takes to recover a point of each resource
+	ManaRegenTurns    = 4
+	StaminaRegenTurns = 2
+)
+
+// castAbility handles "cast <ability> [direction|target]". The target may
+// also follow a preposition, as in "cast firebolt at goblin".
+func (g *Game) castAbility(object, target string) error {
+	if object == "" {
+		return fmt.Errorf("Cast what?")
+	}
+	ability, rest := g.splitAbility(object)
+	if ability == nil {
+		return fmt.Errorf("you don't know an ability called %s", object)
+	}
+	if !ability.IsActive() {
+		return fmt.Errorf("%s is passive, it is always in effect", ability.Name)
+	}
+	if target == "" {
+		target = rest
+	}
+
+	tx, ty := g.Player.X, g.Player.Y
+	switch {
+	case ability.Shape == entity.ShapeSelf || ability.Shape == entity.ShapeBurst:
+	case isValidDirection(normalizeDirection(target)):
+		dx, dy := resolveDirection(normalizeDirection(target))
+		tx, ty = tx+dx, ty+dy
+	default:
+		if target == "" {
+			target = targetNearest
+		}
+		t, err := g.resolveTarget(target)
+		if err != nil {
+			return err
+		}
+		if max(room.Abs(t.Enemy.X-tx), room.Abs(t.Enemy.Y-ty)) > ability.Range {
+			return fmt.Errorf("%s is out of range.", t.Label)
+		}
+		tx, ty = t.Enemy.X, t.Enemy.Y
+	}
+
+	if err := g.cast(g.Player, ability, tx, ty); err != nil {
+		return err
+	}
+	g.makeNoise(NoiseAttack)
+	return nil
+}
+
+// splitAbility finds the player's ability at the start of the words and
+// returns what follows it.
+func (g *Game) splitAbility(object string) (*entity.Ability, string) {
+	words := strings.Fields(object)
+	for i := len(words); i > 0; i-- {
+		if ability := g.Player.FindAbility(strings.Join(words[:i], " ")); ability != nil {
+			return ability, strings.Join(words[i:], " ")
+		}
+	}
+	return nil, ""
+}
+
+// cast uses an active ability from the caster towards tx, ty and applies
+// its damage and effect to every character it reaches.
+func (g *Game) cast(caster *entity.Character, ability *entity.Ability, tx, ty int) error {
+	if err := caster.AbilityReady(ability); err != nil {
+		return err
+	}
+
+	area := g.Room.AbilityArea(ability.Shape, caster.X, caster.Y, tx, ty, ability.Range)
+	caster.SpendFor(ability)
+	g.Logger.LogMessage(logging.LogLevelDebug,
+		fmt.Sprintf("%s casts %s covering %d tiles", caster.Name, ability.Name, len(area)))
+
+	seen := caster == g.Player || g.Room.IsVisible(caster.X, caster.Y)
+	if seen {
+		g.Room.LogView.WriteString(fmt.Sprintf("%s uses %s!\n", caster.Name, ability.Name))
+	}
+
+	if ability.Shape == entity.ShapeSelf {
+		g.applyAbilityEffect(caster, ability, seen)
+		return nil
+	}
+
+	hits := 0
+	for _, tile := range area {
+		target := tile.Entity
+		if target == caster || target == nil || target.HP <= 0 ||
+			(target.ID != entity.ObjPlayer && target.ID != entity.ObjEnemy) {
+			continue
+		}
+		hits++
+
+		report := seen || target == g.Player
+		if ability.Power > 0 {
+			result := combat.Blast(g.Rand, ability.Power, ability.DamageType, target)
+			g.recordAttack(caster, target, result)
+			if report {
+				g.reportBlast(ability, target, result)
+			}
+			if result.Killed {
+				continue
+			}
+		}
+		g.applyAbilityEffect(target, ability, report)
+	}
+
+	if hits == 0 && seen {
+		g.Room.LogView.WriteString(fmt.Sprintf("%s hits nothing.\n", ability.Name))
+	}
+	return nil
+}
+
+func (g *Game) applyAbilityEffect(target *entity.Character, ability *entity.Ability, report bool) {
+	effect := ability.Effect
+	if effect == (entity.Effect{}) {
+		return
+	}
+
+	hp := target.HP
+	target.ApplyEffect(effect)
+	if !report {
+		return
+	}
+
+	switch {
+	case effect.Status != "":
+		g.Room.LogView.WriteString(fmt.Sprintf("%s is %s!\n", target.Name, effect.Status.Adjective()))
+	case target.HP > hp:
+		g.Room.LogView.WriteString(fmt.Sprintf("%s recovers %d HP.\n", target.Name, target.HP-hp))
+	}
+}
+
+func (g *Game) reportBlast(ability *entity.Ability, target *entity.Character, result combat.Result) {
+	verb := "hits"
+	if result.Critical {
+		verb = "critically hits"
+	}
+	message := fmt.Sprintf("%s %s %s for %d %s damage.", ability.Name, verb, target.Name, result.Damage, result.Type)
+	if result.Resisted > 0 {
+		message += fmt.Sprintf(" %s resists %d.", target.Name, result.Resisted)
+	}
+	g.Room.LogView.WriteString(message + "\n")
+
+	if result.Killed {
+		g.Room.LogView.WriteString(fmt.Sprintf("%s is defeated!\n", target.Name))
+	} else {
+		g.Room.LogView.WriteString(fmt.Sprintf("%s has %d HP left.\n", target.Name, target.HP))
+	}
+}
+
+// tickAbilities cools abilities down and recovers mana and stamina.
+func (g *Game) tickAbilities(c *entity.Character) {
+	c.TickCooldowns()
+	if g.Turn%ManaRegenTurns == 0 {
+		c.Recover(entity.ResourceMana, 1)
+	}
+	if g.Turn%StaminaRegenTurns == 0 {
+		c.Recover(entity.ResourceStamina, 1)
+	}
+}
+
+// enemyCast lets a hunting enemy use an ability instead of its behavior.
+// Healing is saved for when it is badly hurt, everything else is only cast
+// when it would reach the player.
+func (g *Game) enemyCast(enemy *entity.Character) bool {
+	if !g.canSeePlayer(enemy) {
+		return false
+	}
+
+	for i := range enemy.Abilities {
+		ability := &enemy.Abilities[i]
+		if !ability.IsActive() || enemy.AbilityReady(ability) != nil {
+			continue
+		}
+
+		if ability.Shape == entity.ShapeSelf {
+			if !isBadlyHurt(enemy) {
+				continue
+			}
+		} else if !g.reachesPlayer(enemy, ability) {
+			continue
+		}
+
+		if err := g.cast(enemy, ability, g.Player.X, g.Player.Y); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) reachesPlayer(enemy *entity.Character, ability *entity.Ability) bool {
+	for _, tile := range g.Room.AbilityArea(ability.Shape, enemy.X, enemy.Y, g.Player.X, g.Player.Y, ability.Range) {
+		if tile.Entity == g.Player {
+			return true
+		}
+	}
+	return false
+}
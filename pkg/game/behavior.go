@@ -0,0 +1,62 @@
+package game
+
+import (
+	"fmt"
+
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/logging"
+)
+
+// Behavior decides what an enemy does on its turn. Enemies pick their
+// behavior by name through entity.Character.Behavior, so new monsters only
+// need a template.
+type Behavior interface {
+	Act(g *Game, enemy *entity.Character)
+}
+
+// BehaviorFunc adapts a plain function to the Behavior interface.
+type BehaviorFunc func(g *Game, enemy *entity.Character)
+
+func (f BehaviorFunc) Act(g *Game, enemy *entity.Character) {
+	f(g, enemy)
+}
+
+var behaviors = map[string]Behavior{
+	entity.BehaviorChase:  BehaviorFunc(chase),
+	entity.BehaviorFlee:   BehaviorFunc(fleeWhenHurt),
+	entity.BehaviorWander: BehaviorFunc(wander),
+	entity.BehaviorGuard:  BehaviorFunc(guard),
+	entity.BehaviorRanged: BehaviorFunc(kite),
+}
+
+// RegisterBehavior makes a behavior available to enemy templates by name.
+func RegisterBehavior(name string, behavior Behavior) {
+	behaviors[name] = behavior
+}
+
+// enemyTurn runs the behavior attached to the enemy.
+func (g *Game) enemyTurn(enemy *entity.Character) {
+	behavior, ok := behaviors[enemy.Behavior]
+	if !ok {
+		g.Logger.LogMessage(logging.LogLevelWarning,
+			fmt.Sprintf("Enemy %s has unknown behavior %q, chasing instead", enemy.Name, enemy.Behavior))
+		behavior = behaviors[entity.BehaviorChase]
+	}
+
+	g.perceive(enemy)
+	g.Logger.LogMessage(logging.LogLevelDebug,
+		fmt.Sprintf("Enemy %s takes its turn (%s, %s)", enemy.Name, enemy.Behavior, enemy.AIState))
+
+	switch enemy.AIState {
+	case entity.AIStateHunting:
+		if !g.enemyCast(enemy) && !flank(g, enemy) {
+			behavior.Act(g, enemy)
+		}
+	case entity.AIStateAlerted:
+		investigate(g, enemy)
+	case entity.AIStateFleeing:
+		scatter(g, enemy)
+	default:
+		idle(g, enemy)
+	}
+}
@@ -26,6 +26,12 @@ const (
 	Down  = -1
 )
 
+// SightRadius is how far the player can see in tiles
+const SightRadius = 8
+
+// MaxMessageHistory is how many messages the journal keeps
+const MaxMessageHistory = 500
+
 const (
 	InputActionMove      = "move"
 	InputActionAttack    = "attack"
@@ -63,4 +69,5 @@ const (
 	InputActionStats     = "stats"
 	InputActionQuests    = "quests"
 	InputActionJournal   = "journal"
+	InputActionScores    = "scores"
 )
@@ -0,0 +1,62 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/logging"
+)
+
+// tickEffects runs a turn of a character's timed effects and returns the
+// cause of death should they kill it. Only what the player can see is
+// reported.
+func (g *Game) tickEffects(c *entity.Character) string {
+	if len(c.Effects) == 0 {
+		return ""
+	}
+
+	var harmful []string
+	for _, effect := range c.Effects {
+		if effect.HP < 0 {
+			harmful = append(harmful, string(effect.Status))
+		}
+	}
+
+	hp, expired := c.TickEffects()
+	g.Logger.LogMessage(logging.LogLevelDebug,
+		fmt.Sprintf("Effects on %s changed HP by %d", c.Name, hp))
+	if c == g.Player && hp < 0 {
+		g.Stats.DamageTaken -= hp
+	}
+
+	cause := "killed by " + strings.Join(harmful, " and ")
+	if c != g.Player && !g.Room.IsVisible(c.X, c.Y) {
+		return cause
+	}
+
+	switch {
+	case hp < 0:
+		g.Room.LogView.WriteString(fmt.Sprintf("%s loses %d HP from %s.\n", c.Name, -hp, strings.Join(harmful, " and ")))
+	case hp > 0:
+		g.Room.LogView.WriteString(fmt.Sprintf("%s regains %d HP.\n", c.Name, hp))
+	}
+	for _, effect := range expired {
+		g.Room.LogView.WriteString(fmt.Sprintf("%s is no longer %s.\n", c.Name, effect.Status.Adjective()))
+	}
+
+	return cause
+}
+
+// stunned reports whether a character loses its action to a stun.
+func (g *Game) stunned(c *entity.Character) bool {
+	if !c.HasStatus(entity.StatusStun) {
+		return false
+	}
+
+	g.Logger.LogMessage(logging.LogLevelDebug, fmt.Sprintf("%s is stunned", c.Name))
+	if c == g.Player || g.Room.IsVisible(c.X, c.Y) {
+		g.Room.LogView.WriteString(fmt.Sprintf("%s is stunned and cannot act.\n", c.Name))
+	}
+	return true
+}
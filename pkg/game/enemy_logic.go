@@ -5,30 +5,207 @@ import (
 
 	"bitcrawler/pkg/entity"
 	"bitcrawler/pkg/logging"
+	"bitcrawler/pkg/pathfinding"
+	"bitcrawler/pkg/room"
 )
 
-func goblinMoveOrAttack(g *Game, enemy *entity.Character) {
-	if g.Turn%2 != 0 {
-		g.Logger.LogMessage(logging.LogLevelDebug,
-			fmt.Sprintf("Enemy %s is waiting for their turn", enemy.Name))
+// crowdCost makes enemies prefer routes that are not blocked by their pack
+const crowdCost = 30
+
+// chase moves towards the player and attacks when it bumps into them.
+func chase(g *Game, enemy *entity.Character) {
+	moveTowards(g, enemy, g.Player.X, g.Player.Y)
+}
+
+// fleeWhenHurt chases the player until its health drops below the flee
+// threshold, then runs away.
+func fleeWhenHurt(g *Game, enemy *entity.Character) {
+	if !isBadlyHurt(enemy) {
+		chase(g, enemy)
 		return
 	}
 
-	g.Logger.LogMessage(logging.LogLevelDebug,
-		fmt.Sprintf("Enemy %s takes its turn", enemy.Name))
+	if !moveAway(g, enemy, g.Player.X, g.Player.Y) && isAdjacent(enemy, g.Player) {
+		// cornered, fight back
+		g.Room.AttackEntity(enemy, g.Player)
+	}
+}
 
-	// calculate the direction vector
-	dx, dy := calculateDirectionVector(g.Player.X, g.Player.Y, enemy.X, enemy.Y)
+// wander shuffles around at random and only fights when the player is
+// right next to it.
+func wander(g *Game, enemy *entity.Character) {
+	if isAdjacent(enemy, g.Player) {
+		g.Room.AttackEntity(enemy, g.Player)
+		return
+	}
+
+	dx, dy := g.Rand.Intn(3)-1, g.Rand.Intn(3)-1
+	if dx == 0 && dy == 0 {
+		return
+	}
+	if !g.canStep(enemy, dx, dy) {
+		return
+	}
+
+	if err := g.Room.Move(enemy, dx, dy); err != nil {
+		g.Logger.LogMessage(logging.LogLevelDebug, err.Error())
+	}
+}
+
+// guard stays close to its home tile and only chases the player while they
+// are within its guard radius.
+func guard(g *Game, enemy *entity.Character) {
+	if distance(g.Player.X, g.Player.Y, enemy.HomeX, enemy.HomeY) <= float64(enemy.GuardRadius) {
+		chase(g, enemy)
+		return
+	}
+
+	if enemy.X != enemy.HomeX || enemy.Y != enemy.HomeY {
+		moveTowards(g, enemy, enemy.HomeX, enemy.HomeY)
+	}
+}
+
+// kite shoots the player from range and backs off when they get too close.
+func kite(g *Game, enemy *entity.Character) {
+	dist := distance(enemy.X, enemy.Y, g.Player.X, g.Player.Y)
+	inSight := g.Room.HasLineOfSight(enemy.X, enemy.Y, g.Player.X, g.Player.Y)
+
+	if inSight && dist < float64(enemy.PreferredRange) && moveAway(g, enemy, g.Player.X, g.Player.Y) {
+		g.Room.LogView.WriteString(fmt.Sprintf("%s backs away from the player\n", enemy.Name))
+		return
+	}
+
+	if inSight && dist <= float64(enemy.Range) {
+		g.Room.LogView.WriteString(fmt.Sprintf("%s fires from a distance.\n", enemy.Name))
+		g.Room.AttackEntity(enemy, g.Player)
+		return
+	}
+
+	chase(g, enemy)
+}
 
-	// Normalize the direction vector
-	dxx, dxy := normalizeVector(dx, dy)
+// moveTowards steps the enemy towards the target. It reports false when
+// there is no path to the target.
+func moveTowards(g *Game, enemy *entity.Character, targetX, targetY int) bool {
+	// find a route around walls and other enemies
+	dx, dy, ok := g.nextStepTowards(enemy, targetX, targetY)
 	g.Logger.LogMessage(logging.LogLevelDebug,
-		fmt.Sprintf("Enemy %s direction vector: (%d, %d)", enemy.Name, dxx, dxy))
+		fmt.Sprintf("Enemy %s direction vector: (%d, %d)", enemy.Name, dx, dy))
 
-	// Move enemy towards player
-	if err := g.Room.Move(enemy, dxx, dxy); err != nil {
+	// only a chase may bump into the player, anything else waits for them
+	// to get out of the way
+	chasing := targetX == g.Player.X && targetY == g.Player.Y
+	if !chasing && enemy.X+dx == g.Player.X && enemy.Y+dy == g.Player.Y {
+		return ok
+	}
+
+	if err := g.Room.Move(enemy, dx, dy); err != nil {
 		g.Logger.LogMessage(logging.LogLevelDebug, err.Error())
-	} else {
+	} else if chasing {
 		g.Room.LogView.WriteString(fmt.Sprintf("%s moves towards the player\n", enemy.Name))
 	}
+	return ok
+}
+
+// moveAway steps to the neighboring tile farthest from the target. It
+// reports false when no step gets the enemy any farther away.
+func moveAway(g *Game, enemy *entity.Character, targetX, targetY int) bool {
+	bestX, bestY := 0, 0
+	best := distance(enemy.X, enemy.Y, targetX, targetY)
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			if !g.canStep(enemy, dx, dy) {
+				continue
+			}
+			if d := distance(enemy.X+dx, enemy.Y+dy, targetX, targetY); d > best {
+				best, bestX, bestY = d, dx, dy
+			}
+		}
+	}
+
+	if bestX == 0 && bestY == 0 {
+		return false
+	}
+
+	if err := g.Room.Move(enemy, bestX, bestY); err != nil {
+		g.Logger.LogMessage(logging.LogLevelDebug, err.Error())
+		return false
+	}
+	return true
+}
+
+// canStep reports whether the character can walk onto the neighboring tile
+// without attacking anything.
+func (g *Game) canStep(character *entity.Character, dx, dy int) bool {
+	if dx == 0 && dy == 0 {
+		return false
+	}
+	x, y := character.X+dx, character.Y+dy
+	return g.Room.InBounds(x, y) && g.Room.Grid[x][y].Entity.ID == entity.ObjEmpty
+}
+
+func isBadlyHurt(character *entity.Character) bool {
+	return character.MaxHP > 0 && character.HP*100 < character.MaxHP*character.FleeThreshold
+}
+
+func isAdjacent(a, b *entity.Character) bool {
+	return max(room.Abs(a.X-b.X), room.Abs(a.Y-b.Y)) == 1
+}
+
+func distance(x1, y1, x2, y2 int) float64 {
+	return room.Distance(float64(x1), float64(y1), float64(x2), float64(y2))
+}
+
+// nextStepTowards returns the direction of the first step on the path from
+// the character to the target and whether there is a way to go. Without a
+// path it heads straight for it.
+func (g *Game) nextStepTowards(character *entity.Character, targetX, targetY int) (int, int, bool) {
+	start := pathfinding.Point{X: character.X, Y: character.Y}
+	goal := pathfinding.Point{X: targetX, Y: targetY}
+
+	path, found := pathfinding.FindPath(start, goal, g.pathCost(character, goal), g.Pathfinding)
+	if len(path) == 0 {
+		g.Logger.LogMessage(logging.LogLevelDebug,
+			fmt.Sprintf("%s found no path to (%d, %d)", character.Name, targetX, targetY))
+		dx, dy := normalizeVector(calculateDirectionVector(targetX, targetY, character.X, character.Y))
+		return dx, dy, false
+	}
+
+	if !found {
+		g.Logger.LogMessage(logging.LogLevelDebug,
+			fmt.Sprintf("%s ran out of search budget, taking a partial path", character.Name))
+	}
+
+	return path[0].X - character.X, path[0].Y - character.Y, true
+}
+
+// pathCost describes the room as the pathfinder sees it for one character.
+// The player only blocks the way when they are not the goal.
+func (g *Game) pathCost(character *entity.Character, goal pathfinding.Point) pathfinding.CostFunc {
+	return func(x, y int) int {
+		if !g.Room.InBounds(x, y) {
+			return pathfinding.Impassable
+		}
+
+		occupant := g.Room.Grid[x][y].Entity
+		switch occupant.ID {
+		case entity.ObjWall, entity.ObjExit:
+			return pathfinding.Impassable
+		case entity.ObjPlayer:
+			if x != goal.X || y != goal.Y {
+				return pathfinding.Impassable
+			}
+		case entity.ObjEnemy:
+			if occupant == character {
+				return 0
+			}
+			// bodies stay on the grid and block movement for good
+			if occupant.HasDied {
+				return pathfinding.Impassable
+			}
+			return crowdCost
+		}
+
+		return 0
+	}
 }
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"testing"
+
+	"bitcrawler/pkg/entity"
+)
+
+func TestIdleEnemyWalksAroundPlayer(t *testing.T) {
+	tests := []struct {
+		name     string
+		corridor bool
+		wantHome bool
+	}{
+		{"open room", false, true},
+		{"player blocks the corridor", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := newTestGame(t)
+			if tt.corridor {
+				for x := 1; x < g.Room.Width-1; x++ {
+					for y := 1; y < g.Room.Height-1; y++ {
+						if y != g.Player.Y {
+							g.Room.SetTile(x, y, entity.ObjWall)
+						}
+					}
+				}
+			}
+
+			enemy := entity.NewEnemy(entity.GoblinEnemyTemplate)
+			placeCharacter(g, enemy, 3, g.Player.Y)
+			enemy.HomeX, enemy.HomeY = 9, g.Player.Y
+
+			for i := 0; i < 10; i++ {
+				idle(g, enemy)
+			}
+
+			if g.Player.HP != g.Player.MaxHP {
+				t.Errorf("idle enemy attacked the player, HP %d/%d", g.Player.HP, g.Player.MaxHP)
+			}
+			if home := enemy.X == enemy.HomeX && enemy.Y == enemy.HomeY; home != tt.wantHome {
+				t.Errorf("enemy at (%d, %d), home = %v, want %v", enemy.X, enemy.Y, home, tt.wantHome)
+			}
+		})
+	}
+}
@@ -1,22 +1,60 @@
 package game
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"io"
+	"math/rand"
+	"strings"
 	"time"
 
 	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/input"
 	"bitcrawler/pkg/logging"
+	"bitcrawler/pkg/pathfinding"
+	"bitcrawler/pkg/render"
 	"bitcrawler/pkg/room"
 )
 
 type Game struct {
-	Room      *room.Room
-	Player    *entity.Character
-	Enemies   []*entity.Character
-	Turn      int
-	Logger    *logging.Logger
-	StartTime time.Time
+	Room       *room.Room
+	Player     *entity.Character
+	Enemies    []*entity.Character
+	Turn       int
+	Logger     *logging.Logger
+	StartTime  time.Time
+	Seed       int64
+	Rand       *rand.Rand
+	RandSource *Source
+	Input      input.Source
+	Renderer   render.Renderer
+	RoomWidth  int
+	RoomHeight int
+	Algorithm  string
+	FinalLevel int
+	GameOver   bool
+	// Restart asks for a new run once this one is over
+	Restart bool
+
+	Stats        RunStats
+	CauseOfDeath string
+
+	EnemyDensity float64
+	Difficulty   string
+	Pathfinding  pathfinding.Options
+
+	// Messages is the history shown in the message pane
+	Messages      []string
+	messageScroll int
+
+	// loudest noise the player made this turn
+	noise int
+	// energy the last player action costs
+	actionCost int
+	// turns of rest left
+	resting int
+	// terminal size, looked up again on each new level
+	screenColumns, screenRows int
 }
 
 var (
@@ -57,57 +95,126 @@ var (
 		InputActionStats,
 		InputActionQuests,
 		InputActionJournal,
+		InputActionScores,
 	}
 )
 
 func (g *Game) ProcessTurn() {
-	g.Turn = (g.Turn + 1) % 256
-	g.Logger.LogMessage(logging.LogLevelDebug, fmt.Sprintf("Game turn %d", g.Turn))
+	// let the clock run until the player is ready to act
+	g.advanceTime()
+	if g.GameOver {
+		return
+	}
 
-	g.Room.DrawRoom()
-	g.Logger.LogMessage(logging.LogLevelDebug, "Room drawn")
+	g.updateFieldOfView()
+	g.updateCamera()
 
-	fmt.Println("Player's turn. Enter a command (e.g., move, attack):")
-	command, err := getUserInput()
-	if err != nil {
-		fmt.Println("Error reading input:", err)
-		return
+	if g.stunned(g.Player) {
+		g.render("")
+		g.actionCost = ActionCost
+	} else if g.resting > 0 {
+		g.render("")
+		if err := g.rest(); err != nil {
+			g.Room.LogView.WriteString(err.Error() + "\n")
+			return
+		}
+	} else {
+		g.render("Player's turn. Enter a command (e.g., move, attack):")
+		command, err := g.Input.ReadCommand()
+		if errors.Is(err, io.EOF) {
+			g.Logger.LogMessage(logging.LogLevelInfo, "Input ended")
+			g.endRun("No more commands, ending the game.")
+			return
+		}
+		if err != nil {
+			g.show("Error reading input: " + err.Error())
+			return
+		}
+		command = strings.ToLower(strings.TrimSpace(command))
+		g.Logger.LogMessage(logging.LogLevelDebug,
+			fmt.Sprintf("Input: %s", command))
+
+		if err := g.resolveUserInput(command); err != nil {
+			g.Room.LogView.WriteString(err.Error() + "\n")
+			return
+		}
+		g.Logger.LogMessage(logging.LogLevelDebug,
+			fmt.Sprintf("Player action resolved: %s", command))
 	}
-	g.Logger.LogMessage(logging.LogLevelDebug,
-		fmt.Sprintf("Input: %s", command))
 
-	if err := g.resolveUserInput(command); err != nil {
-		g.Room.LogView.WriteString(err.Error() + "\n")
+	if g.GameOver {
 		return
 	}
-	g.Logger.LogMessage(logging.LogLevelDebug,
-		fmt.Sprintf("Player action resolved: %s", command))
 
-	if g.Player.HasExited {
-		g.Room.LogView.WriteString("You have exited the game.\n")
-		os.Exit(0)
-	}
+	g.Player.Energy -= g.actionCost
 
-	// Example: Enemy turn
-	for _, enemy := range g.Enemies {
-		if enemy.HP <= 0 && enemy.HasDied {
-			g.Logger.LogMessage(logging.LogLevelDebug,
-				fmt.Sprintf("Enemy %s is dead and cannot take its turn", enemy.Name))
-			continue
-		} else if enemy.HP <= 0 && !enemy.HasDied {
-			g.Logger.LogMessage(logging.LogLevelDebug,
-				fmt.Sprintf("Enemy %s has died", enemy.Name))
-			enemy.HasDied = true
-			g.Room.LogView.WriteString(enemy.DeathMessage)
-			continue
+	if g.Player.HasExited {
+		if g.isFinalLevel() {
+			g.Logger.LogMessage(logging.LogLevelInfo, "Player escaped the dungeon")
+			g.endRun(fmt.Sprintf("You escaped the dungeon after %d levels!", g.Room.Level))
+			g.show(fmt.Sprintf("Score: %d", g.score(true)))
+			g.recordRun(outcomeEscaped)
+			return
 		}
 
-		if enemy.Name == "Goblin" || enemy.Name == "Goblin Leader" {
-			goblinMoveOrAttack(g, enemy)
+		if err := g.descend(); err != nil {
+			g.Logger.LogMessage(logging.LogLevelError, err.Error())
+			g.endRun("Error generating the next level: " + err.Error())
 		}
 	}
 }
 
+// NewRand creates the random source for a run. A seed of zero picks one
+// from the clock so every run differs unless a seed is requested.
+func NewRand(seed int64) (int64, *rand.Rand, *Source) {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	source := NewSource(seed)
+	return seed, rand.New(source), source
+}
+
+// Source is a seeded random source that counts how many values it has
+// produced, so a saved game can rebuild it at the same point.
+type Source struct {
+	src   rand.Source64
+	Draws int64
+}
+
+func NewSource(seed int64) *Source {
+	return &Source{src: rand.NewSource(seed).(rand.Source64)}
+}
+
+func (s *Source) Int63() int64 {
+	s.Draws++
+	return s.src.Int63()
+}
+
+func (s *Source) Uint64() uint64 {
+	s.Draws++
+	return s.src.Uint64()
+}
+
+func (s *Source) Seed(seed int64) {
+	s.src.Seed(seed)
+	s.Draws = 0
+}
+
+// skip advances the source as if n values had been drawn.
+func (s *Source) skip(n int64) {
+	for ; n > 0; n-- {
+		s.Int63()
+	}
+}
+
+// endRun finishes the game and prints a summary of the run, including the
+// seed to regenerate it.
+func (g *Game) endRun(message string) {
+	g.show(message)
+	g.show(g.summary())
+	g.GameOver = true
+}
+
 func (g *Game) movePlayerOnInput(input string) error {
 	if len(input) == 0 {
 		return fmt.Errorf("cannot move without a direction")
@@ -139,16 +246,19 @@ func (g *Game) movePlayerOnInput(input string) error {
 		g.Room.LogView.WriteString(err.Error() + "\n")
 	} else {
 		g.Room.LogView.WriteString(fmt.Sprintf("%s moves %s\n", g.Player.Name, input))
+		g.describeFloor()
 	}
 
 	return nil
 }
 
 func (g *Game) resolveUserInput(input string) error {
-	action, object, err := resolveActionObject(input)
+	cmd, err := ParseCommand(input)
 	if err != nil {
-		return fmt.Errorf("invalid command")
+		return err
 	}
+	action, object := cmd.Verb, cmd.Object
+	g.actionCost = actionCost(action)
 
 	switch action {
 	case InputActionMove:
@@ -159,9 +269,14 @@ func (g *Game) resolveUserInput(input string) error {
 		if err := g.movePlayerOnInput(object); err != nil {
 			g.Room.LogView.WriteString(err.Error() + "\n")
 		}
+		g.makeNoise(NoiseMove)
 	case InputActionAttack:
 		if !isValidDirection(object) {
-			return fmt.Errorf("You can't attack that way.")
+			if err := g.attackTarget(object); err != nil {
+				return err
+			}
+			g.makeNoise(NoiseAttack)
+			break
 		}
 
 		playerX, playerY := g.Player.X, g.Player.Y
@@ -170,20 +285,41 @@ func (g *Game) resolveUserInput(input string) error {
 		if err := g.Room.AttackDirection(playerX, playerY, playerX+attackX, playerY+attackY); err != nil {
 			g.Room.LogView.WriteString(err.Error() + "\n")
 		}
-	case InputActionUse:
+		g.makeNoise(NoiseAttack)
+	case InputActionUse, InputActionDrink, InputActionEat:
+		if err := g.consumeItem(action, object); err != nil {
+			return err
+		}
 	case InputActionLook:
+		g.look()
 	case InputActionExamine:
+		if err := g.examine(object); err != nil {
+			return err
+		}
 	case InputActionOpen:
 	case InputActionClose:
 	case InputActionPick:
+		if err := g.pickItem(object); err != nil {
+			return err
+		}
 	case InputActionDrop:
+		if err := g.dropItem(object); err != nil {
+			return err
+		}
 	case InputActionTalk:
 	case InputActionRead:
 	case InputActionCast:
+		if err := g.castAbility(object, cmd.Target); err != nil {
+			return err
+		}
 	case InputActionEquip:
+		if err := g.equipItem(object); err != nil {
+			return err
+		}
 	case InputActionUnequip:
-	case InputActionDrink:
-	case InputActionEat:
+		if err := g.unequipItem(object); err != nil {
+			return err
+		}
 	case InputActionClimb:
 	case InputActionSwim:
 	case InputActionJump:
@@ -192,18 +328,39 @@ func (g *Game) resolveUserInput(input string) error {
 	case InputActionHide:
 	case InputActionSearch:
 	case InputActionRest:
+		if err := g.startRest(object); err != nil {
+			return err
+		}
 	case InputActionWait:
+		g.Room.LogView.WriteString(fmt.Sprintf("%s waits.\n", g.Player.Name))
 	case InputActionSleep:
 	case InputActionSave:
+		if err := g.Save(object); err != nil {
+			return err
+		}
+		g.Room.LogView.WriteString(fmt.Sprintf("Game saved to slot %s.\n", object))
 	case InputActionLoad:
-	case InputActionQuit:
-	case InputActionExit:
+		if err := g.Load(object); err != nil {
+			return err
+		}
+		g.Room.LogView.WriteString(fmt.Sprintf("Game loaded from slot %s.\n", object))
+	case InputActionQuit, InputActionExit:
+		g.Logger.LogMessage(logging.LogLevelInfo, "Player quit the game")
+		g.endRun("You abandon your quest.")
 	case InputActionHelp:
 	case InputActionInventory:
+		g.showInventory()
 	case InputActionStatus:
 	case InputActionStats:
 	case InputActionQuests:
 	case InputActionJournal:
+		if err := g.scrollMessages(object); err != nil {
+			return err
+		}
+	case InputActionScores:
+		if err := g.showHighScores(); err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown action")
 	}
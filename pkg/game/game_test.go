@@ -0,0 +1,99 @@
+package game
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"bitcrawler/pkg/config"
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/input"
+	"bitcrawler/pkg/logging"
+	"bitcrawler/pkg/render"
+	"bitcrawler/pkg/room"
+)
+
+// newTestGame sets up a game on an open room with the player in the middle.
+// Commands are played from a script and frames are drawn as plain text.
+func newTestGame(t *testing.T, commands ...string) (*Game, *bytes.Buffer) {
+	t.Helper()
+
+	logger, err := logging.NewLogger(logging.LogLevelError, filepath.Join(t.TempDir(), "game.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seed, rng, source := NewRand(1)
+	out := &bytes.Buffer{}
+	g := &Game{
+		StartTime:  time.Now(),
+		Seed:       seed,
+		Rand:       rng,
+		RandSource: source,
+		Logger:     logger,
+		Input:      input.NewScripted(commands...),
+		Renderer:   &render.Plain{Out: out},
+		Player: &entity.Character{
+			ID:      entity.ObjPlayer,
+			Name:    "Hero",
+			HP:      100,
+			MaxHP:   100,
+			Attack:  10,
+			Defense: 5,
+			Speed:   entity.NormalSpeed,
+			Visual:  '@',
+		},
+		Room:       room.NewRoom(12, 8, 1, rng),
+		RoomWidth:  12,
+		RoomHeight: 8,
+		Difficulty: config.DifficultyNormal,
+	}
+	g.Room.OnAttack = g.recordAttack
+	placeCharacter(g, g.Player, 6, 4)
+	return g, out
+}
+
+// placeCharacter puts a character on the map, adding it to the enemies
+// unless it is the player.
+func placeCharacter(g *Game, c *entity.Character, x, y int) {
+	c.X, c.Y = x, y
+	c.PreviousX, c.PreviousY = x, y
+	g.Room.AddEntity(&room.Coordinate{X: x, Y: y, Entity: c})
+	if c != g.Player {
+		g.Enemies = append(g.Enemies, c)
+	}
+}
+
+func TestProcessTurnScripted(t *testing.T) {
+	g, out := newTestGame(t, "move east", "look", "quit")
+	for turns := 0; !g.GameOver; turns++ {
+		if turns > 10 {
+			t.Fatal("game did not end after the script ran out")
+		}
+		g.ProcessTurn()
+	}
+
+	if g.Player.X != 7 || g.Player.Y != 4 {
+		t.Errorf("player at (%d, %d), want (7, 4)", g.Player.X, g.Player.Y)
+	}
+
+	output := out.String()
+	if strings.Contains(output, "\x1b") {
+		t.Error("plain output contains escape codes")
+	}
+
+	// the frame drawn for the look shows the player one step east
+	frame := strings.Join([]string{
+		"# . . . . . . . . . . #",
+		"# . . . . . . @ . . . #",
+		"# . . . . . . . . . . #",
+	}, "\n")
+	messages := "Hero moves east\nHero looks around.\nYou see no enemies.\n"
+	for _, want := range []string{frame, messages, "You abandon your quest."} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output does not contain %q:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"bitcrawler/pkg/input"
+	"bitcrawler/pkg/logging"
+)
+
+var (
+	restartAnswers = []string{"restart", "r", "yes", "y"}
+	quitAnswers    = []string{InputActionQuit, InputActionExit, "q", "no", "n"}
+)
+
+// checkPlayerAlive ends the run when the player has no HP left and
+// reports whether they are still alive.
+func (g *Game) checkPlayerAlive(cause string) bool {
+	if g.Player.HP > 0 {
+		return true
+	}
+
+	if !g.Player.HasDied {
+		g.Player.HasDied = true
+		g.CauseOfDeath = cause
+		g.Logger.LogMessage(logging.LogLevelInfo, "Player died: "+cause)
+		g.render("")
+		g.endRun("*** You have died ***\n" + fmt.Sprintf("%s was %s on level %d.", g.Player.Name, cause, g.Room.Level))
+		g.show(fmt.Sprintf("Score: %d", g.score(false)))
+		g.recordRun(cause)
+		g.offerRestart()
+	}
+
+	return false
+}
+
+// summary describes the run for the game over screen.
+func (g *Game) summary() string {
+	kills := fmt.Sprintf("Kills: %d", g.Stats.TotalKills())
+	if g.Stats.TotalKills() > 0 {
+		kills += fmt.Sprintf(" (%s)", g.Stats.describeKills())
+	}
+
+	lines := []string{
+		fmt.Sprintf("Turns: %d", g.Turn),
+		fmt.Sprintf("Time: %s", time.Since(g.StartTime).Round(time.Second)),
+		kills,
+		fmt.Sprintf("Damage dealt: %d, taken: %d", g.Stats.DamageDealt, g.Stats.DamageTaken),
+		fmt.Sprintf("Gold: %d", g.gold()),
+		fmt.Sprintf("Level reached: %d", g.Room.Level),
+		fmt.Sprintf("Seed: %d", g.Seed),
+	}
+	return strings.Join(lines, "\n")
+}
+
+// offerRestart asks whether to play again. Running out of input counts as
+// quitting.
+func (g *Game) offerRestart() {
+	for {
+		g.show("Play again? Type restart or quit (r/q).")
+		answer, err := input.ReadAnswer(g.Input)
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			g.show("Error reading input: " + err.Error())
+			return
+		}
+
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		switch {
+		case slices.Contains(restartAnswers, answer):
+			g.Logger.LogMessage(logging.LogLevelInfo, "Player restarts")
+			g.Restart = true
+			return
+		case slices.Contains(quitAnswers, answer):
+			return
+		}
+	}
+}
+
+// killedBy describes a death at the hands of a character.
+func killedBy(name string) string {
+	if name == "" {
+		return "killed by an unknown foe"
+	}
+	article := "a"
+	if strings.ContainsRune("aeiouAEIOU", rune(name[0])) {
+		article = "an"
+	}
+	return fmt.Sprintf("killed by %s %s", article, name)
+}
@@ -0,0 +1,180 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"bitcrawler/pkg/entity"
+)
+
+const itemAll = "all"
+
+func (g *Game) pickItem(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("pick up what?")
+	}
+
+	x, y := g.Player.X, g.Player.Y
+	if name == itemAll {
+		items := g.Room.Grid[x][y].Items
+		if len(items) == 0 {
+			return fmt.Errorf("there is nothing here to pick up")
+		}
+		for _, item := range append([]*entity.Item(nil), items...) {
+			if err := g.pickItem(strings.ToLower(item.Name)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(g.Player.Inventory) >= g.Player.InventoryCapacity {
+		return entity.ErrInventoryFull
+	}
+
+	item, err := g.Room.PickItem(x, y, name)
+	if err != nil {
+		return err
+	}
+
+	if err := g.Player.AddItem(item); err != nil {
+		g.Room.DropItem(x, y, item)
+		return err
+	}
+
+	g.Room.LogView.WriteString(fmt.Sprintf("%s picks up the %s.\n", g.Player.Name, item.Name))
+	return nil
+}
+
+func (g *Game) dropItem(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("drop what?")
+	}
+
+	item, err := g.Player.RemoveItem(name)
+	if err != nil {
+		return err
+	}
+
+	g.Room.DropItem(g.Player.X, g.Player.Y, item)
+	g.Room.LogView.WriteString(fmt.Sprintf("%s drops the %s.\n", g.Player.Name, item.Name))
+	return nil
+}
+
+func (g *Game) equipItem(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("equip what?")
+	}
+
+	item, err := g.Player.Equip(name)
+	if err != nil {
+		return err
+	}
+
+	g.Room.LogView.WriteString(fmt.Sprintf("%s equips the %s.\n", g.Player.Name, item.Name))
+	return nil
+}
+
+func (g *Game) unequipItem(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("unequip what?")
+	}
+
+	item, err := g.Player.Unequip(name)
+	if err != nil {
+		return err
+	}
+
+	g.Room.LogView.WriteString(fmt.Sprintf("%s unequips the %s.\n", g.Player.Name, item.Name))
+	return nil
+}
+
+// consumeItem drinks, eats or uses up a consumable from the inventory.
+func (g *Game) consumeItem(verb, name string) error {
+	item := g.Player.FindItem(name)
+	if item == nil {
+		return fmt.Errorf("you are not carrying %s", name)
+	}
+	if item.Kind != entity.ItemConsumable {
+		return fmt.Errorf("you can't %s the %s", verb, item.Name)
+	}
+
+	if _, err := g.Player.RemoveItem(item.Name); err != nil {
+		return err
+	}
+
+	hp := g.Player.HP
+	g.Player.ApplyEffect(item.Effect)
+
+	message := fmt.Sprintf("%s %ss the %s", g.Player.Name, verb, item.Name)
+	if item.Effect.Status != "" {
+		message += fmt.Sprintf(" and is %s", item.Effect.Status.Adjective())
+	} else if g.Player.HP != hp {
+		message += fmt.Sprintf(" and recovers %d HP", g.Player.HP-hp)
+	}
+	g.Room.LogView.WriteString(message + ".\n")
+	return nil
+}
+
+func (g *Game) showInventory() {
+	if len(g.Player.Equipment) > 0 {
+		g.Room.LogView.WriteString("Equipped:\n")
+	}
+	for _, slot := range entity.EquipSlots {
+		if item, ok := g.Player.Equipment[slot]; ok {
+			g.Room.LogView.WriteString(fmt.Sprintf("  %s: %s%s\n", slot, item.Name, describeEffect(item.Effect)))
+		}
+	}
+
+	if len(g.Player.Inventory) == 0 {
+		g.Room.LogView.WriteString("You are not carrying anything.\n")
+		return
+	}
+
+	g.Room.LogView.WriteString(fmt.Sprintf("Inventory (%d/%d):\n",
+		len(g.Player.Inventory), g.Player.InventoryCapacity))
+	for _, item := range g.Player.Inventory {
+		g.Room.LogView.WriteString(fmt.Sprintf("  %c %s - %s%s\n",
+			item.Visual, item.Name, item.Description, describeEffect(item.Effect)))
+	}
+}
+
+func describeEffect(effect entity.Effect) string {
+	var mods []string
+	if effect.Attack != 0 {
+		mods = append(mods, fmt.Sprintf("%+d attack", effect.Attack))
+	}
+	if effect.Defense != 0 {
+		mods = append(mods, fmt.Sprintf("%+d defense", effect.Defense))
+	}
+	if effect.Speed != 0 {
+		mods = append(mods, fmt.Sprintf("%+d speed", effect.Speed))
+	}
+	switch {
+	case effect.HP != 0 && effect.Duration > 0:
+		mods = append(mods, fmt.Sprintf("%+d HP per turn", effect.HP))
+	case effect.HP != 0:
+		mods = append(mods, fmt.Sprintf("%+d HP", effect.HP))
+	}
+	if effect.Status != "" {
+		mods = append(mods, fmt.Sprintf("%s for %d turns", effect.Status.Adjective(), effect.Duration))
+	}
+	if len(mods) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(mods, ", ") + ")"
+}
+
+// describeFloor tells the player what is lying on their tile.
+func (g *Game) describeFloor() {
+	items := g.Room.Grid[g.Player.X][g.Player.Y].Items
+	if len(items) == 0 {
+		return
+	}
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	g.Room.LogView.WriteString(fmt.Sprintf("You see here: %s\n", strings.Join(names, ", ")))
+}
@@ -0,0 +1,123 @@
+package game
+
+import (
+	"fmt"
+	"math"
+
+	"bitcrawler/pkg/config"
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/gear"
+	"bitcrawler/pkg/generator"
+	"bitcrawler/pkg/logging"
+	"bitcrawler/pkg/room"
+)
+
+// GenerateLevel builds a new room for the given level and places the
+// player, the exit, enemies and loot in it. The player keeps everything
+// they carry between levels.
+func (g *Game) GenerateLevel(level int) error {
+	// Initialize the room for the level
+	rm, err := generator.Generate(g.Algorithm, g.RoomWidth, g.RoomHeight, level, g.Rand)
+	if err != nil {
+		return err
+	}
+	g.Logger.LogMessage(logging.LogLevelDebug,
+		fmt.Sprintf("Room initialized for level %d with dimensions: %d x %d using %s",
+			level, rm.Width, rm.Height, g.Algorithm))
+	g.screenColumns, g.screenRows = 0, 0
+
+	// Find an empty space in the room to place the player
+	playerX, playerY := rm.FindEmptySpace()
+	if playerX == -1 && playerY == -1 {
+		return fmt.Errorf("cannot initialize room, no empty space found")
+	}
+
+	g.Player.X, g.Player.Y = playerX, playerY
+	g.Player.PreviousX, g.Player.PreviousY = playerX, playerY
+	g.Player.HasExited = false
+	rm.AddEntity(&room.Coordinate{X: playerX, Y: playerY, Entity: g.Player})
+	g.Logger.LogMessage(logging.LogLevelDebug,
+		fmt.Sprintf("Player placed at coordinates: (%d, %d)", playerX, playerY))
+
+	// Add the exit at the farthest reachable distance from the player
+	exitX, exitY := rm.FindFarthestReachable(playerX, playerY)
+	if exitX == -1 && exitY == -1 {
+		return fmt.Errorf("cannot initialize room, no reachable space for the exit")
+	}
+	exit := &entity.Character{
+		X:      exitX,
+		Y:      exitY,
+		ID:     entity.ObjExit,
+		Name:   "Exit",
+		Visual: '>',
+	}
+	rm.AddEntity(&room.Coordinate{X: exitX, Y: exitY, Entity: exit})
+	g.Logger.LogMessage(logging.LogLevelDebug,
+		fmt.Sprintf("Exit added at coordinates: (%d, %d)", exitX, exitY))
+
+	// Setup our enemies, deeper levels have more and stronger packs
+	var enemies []*entity.Character
+	packs := int(math.Round(float64(1+(level-1)/3) * g.EnemyDensity))
+	for i := 0; i < packs; i++ {
+		goblinEnemyCount := g.Rand.Intn(2) + min(level-1, 2)
+		enemies = append(enemies, rm.PlaceGoblinPack(goblinEnemyCount, true)...)
+	}
+	enemies = append(enemies, rm.AddRandomEntities(entity.CaveRatTemplate, g.Rand.Intn(3))...)
+	if level > 1 {
+		enemies = append(enemies, rm.AddRandomEntities(entity.GoblinArcherTemplate, g.Rand.Intn(level))...)
+		enemies = append(enemies, rm.AddRandomEntities(entity.GoblinSentryTemplate, g.Rand.Intn(2))...)
+	}
+	if level > 2 {
+		enemies = append(enemies, rm.AddRandomEntities(entity.GoblinShamanTemplate, g.Rand.Intn(2)+1)...)
+	}
+	for _, enemy := range enemies {
+		entity.ScaleToLevel(enemy, level)
+		g.applyDifficulty(enemy)
+		enemy.HomeX, enemy.HomeY = enemy.X, enemy.Y
+	}
+	g.Logger.LogMessage(logging.LogLevelDebug,
+		fmt.Sprintf("%d enemies placed on level %d", len(enemies), level))
+
+	// Scatter some loot around the room
+	rm.AddRandomItems(gear.ItemGoldPouch, g.Rand.Intn(3)+1)
+	rm.AddRandomItems(gear.ItemGemstone, g.Rand.Intn(2))
+	rm.AddRandomItems(gear.Weapons[g.Rand.Intn(len(gear.Weapons))], 1)
+	rm.AddRandomItems(gear.Armor[g.Rand.Intn(len(gear.Armor))], 1)
+	rm.AddRandomItems(gear.Potions[g.Rand.Intn(len(gear.Potions))], g.Rand.Intn(2)+1)
+
+	g.Room = rm
+	g.Room.OnAttack = g.recordAttack
+	g.Enemies = enemies
+	g.Stats.Depth = max(g.Stats.Depth, level)
+
+	return nil
+}
+
+// descend moves the player to the next level of the dungeon.
+func (g *Game) descend() error {
+	next := g.Room.Level + 1
+	if err := g.GenerateLevel(next); err != nil {
+		return err
+	}
+
+	g.Logger.LogMessage(logging.LogLevelInfo, fmt.Sprintf("Player descended to level %d", next))
+	g.Room.LogView.WriteString(fmt.Sprintf("You descend deeper into the dungeon. Level %d.\n", next))
+	return nil
+}
+
+func (g *Game) applyDifficulty(enemy *entity.Character) {
+	switch g.Difficulty {
+	case config.DifficultyEasy:
+		enemy.HP = max(1, enemy.HP*3/4)
+		enemy.Attack = max(0, enemy.Attack-2)
+	case config.DifficultyHard:
+		enemy.HP = enemy.HP * 5 / 4
+		enemy.Attack += 2
+		enemy.Defense++
+	}
+	enemy.MaxHP = enemy.HP
+}
+
+func (g *Game) isFinalLevel() bool {
+	return g.FinalLevel > 0 && g.Room.Level >= g.FinalLevel
+}
@@ -0,0 +1,195 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/room"
+)
+
+// look describes what the player can see around them.
+func (g *Game) look() {
+	px, py := g.Player.X, g.Player.Y
+	var lines, items, bodies []string
+	exit := ""
+
+	for x := 0; x < g.Room.Width; x++ {
+		for y := 0; y < g.Room.Height; y++ {
+			if (x == px && y == py) || !g.Room.IsVisible(x, y) {
+				continue
+			}
+
+			where := relativePosition(x-px, y-py)
+			tile := g.Room.Grid[x][y]
+			for _, item := range tile.Items {
+				items = append(items, fmt.Sprintf("%s (%s)", item.Name, where))
+			}
+
+			switch e := tile.Entity; {
+			case e == nil:
+			case e.ID == entity.ObjExit:
+				exit = where
+			case e.ID == entity.ObjEnemy && (e.HasDied || e.HP <= 0):
+				bodies = append(bodies, fmt.Sprintf("%s (%s)", e.Name, where))
+			}
+		}
+	}
+
+	targets := g.visibleTargets()
+	if len(targets) == 0 {
+		lines = append(lines, "You see no enemies.")
+	}
+	for _, t := range targets {
+		lines = append(lines, fmt.Sprintf("%s, %s, %s.",
+			t.Label, t.Enemy.HealthDescription(), relativePosition(t.Enemy.X-px, t.Enemy.Y-py)))
+	}
+
+	if exit != "" {
+		lines = append(lines, fmt.Sprintf("A stairway leads down, %s.", exit))
+	}
+	if len(items) > 0 {
+		lines = append(lines, "You spot: "+strings.Join(items, ", "))
+	}
+	if len(bodies) > 0 {
+		lines = append(lines, "Bodies: "+strings.Join(bodies, ", "))
+	}
+
+	g.Room.LogView.WriteString(fmt.Sprintf("%s looks around.\n", g.Player.Name))
+	for _, line := range lines {
+		g.Room.LogView.WriteString(line + "\n")
+	}
+	g.describeFloor()
+}
+
+// examine describes an enemy, an item or the tile in a direction.
+func (g *Game) examine(object string) error {
+	if isValidDirection(object) {
+		dx, dy := resolveDirection(object)
+		return g.examineTile(g.Player.X+dx, g.Player.Y+dy)
+	}
+
+	t, err := g.resolveTarget(object)
+	if err == nil {
+		g.describeCharacter(t.Enemy)
+		return nil
+	}
+	// only look further when no enemy matched, not when several did
+	var noTarget *noTargetError
+	if !errors.As(err, &noTarget) {
+		return err
+	}
+
+	if item := g.findVisibleItem(object); item != nil {
+		g.Room.LogView.WriteString(fmt.Sprintf("%s: %s.%s\n", item.Name, item.Description, describeEffect(item.Effect)))
+		return nil
+	}
+
+	for _, enemy := range g.Enemies {
+		if enemy.HP <= 0 && g.Room.IsVisible(enemy.X, enemy.Y) && entity.MatchesName(enemy.Name, object) {
+			g.describeCharacter(enemy)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("You don't see any %s here.", object)
+}
+
+func (g *Game) examineTile(x, y int) error {
+	if !g.Room.InBounds(x, y) || !g.Room.IsVisible(x, y) {
+		return fmt.Errorf("You can't see anything there.")
+	}
+
+	tile := g.Room.Grid[x][y]
+	switch e := tile.Entity; {
+	case e == nil || e.ID == entity.ObjEmpty:
+		g.Room.LogView.WriteString("Bare stone floor.\n")
+	case e.ID == entity.ObjWall:
+		g.Room.LogView.WriteString("A wall of rough, damp stone.\n")
+	case e.ID == entity.ObjExit:
+		g.Room.LogView.WriteString("A stairway leads further down into the dark.\n")
+	default:
+		g.describeCharacter(e)
+	}
+
+	for _, item := range tile.Items {
+		g.Room.LogView.WriteString(fmt.Sprintf("%s lies here.\n", item.Name))
+	}
+	return nil
+}
+
+func (g *Game) describeCharacter(c *entity.Character) {
+	description := c.Description
+	if description == "" {
+		description = fmt.Sprintf("It is %s.", c.Name)
+	}
+	g.Room.LogView.WriteString(description + "\n")
+	g.Room.LogView.WriteString(c.HealthText() + "\n")
+}
+
+// findVisibleItem looks for an item carried, worn or lying in view.
+func (g *Game) findVisibleItem(name string) *entity.Item {
+	if item := g.Player.FindItem(name); item != nil {
+		return item
+	}
+	for _, slot := range entity.EquipSlots {
+		if item := g.Player.Equipment[slot]; item != nil && entity.MatchesItem(item, name) {
+			return item
+		}
+	}
+
+	for x := 0; x < g.Room.Width; x++ {
+		for y := 0; y < g.Room.Height; y++ {
+			if !g.Room.IsVisible(x, y) {
+				continue
+			}
+			for _, item := range g.Room.Grid[x][y].Items {
+				if entity.MatchesItem(item, name) {
+					return item
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// relativePosition describes an offset from the player, e.g. "3 steps north".
+func relativePosition(dx, dy int) string {
+	steps := max(room.Abs(dx), room.Abs(dy))
+	if steps == 0 {
+		return "here"
+	}
+
+	// mostly straight lines read better than a diagonal
+	if room.Abs(dx) > 2*room.Abs(dy) {
+		dy = 0
+	} else if room.Abs(dy) > 2*room.Abs(dx) {
+		dx = 0
+	}
+
+	var direction string
+	switch nx, ny := normalizeVector(dx, dy); {
+	case nx == 0 && ny == Up:
+		direction = DirectionNorth
+	case nx == 0 && ny == Down:
+		direction = DirectionSouth
+	case nx == Right && ny == 0:
+		direction = DirectionEast
+	case nx == Left && ny == 0:
+		direction = DirectionWest
+	case nx == Right && ny == Up:
+		direction = DirectionNortheast
+	case nx == Left && ny == Up:
+		direction = DirectionNorthwest
+	case nx == Right && ny == Down:
+		direction = DirectionSoutheast
+	default:
+		direction = DirectionSouthwest
+	}
+
+	if steps == 1 {
+		return "1 step " + direction
+	}
+	return fmt.Sprintf("%d steps %s", steps, direction)
+}
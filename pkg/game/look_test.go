@@ -0,0 +1,45 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"bitcrawler/pkg/entity"
+)
+
+func TestExamine(t *testing.T) {
+	tests := []struct {
+		name    string
+		object  string
+		wantErr string
+		want    string
+	}{
+		{"same name is ambiguous", "goblin", "Which one? goblin 1, goblin 2.", ""},
+		{"numbered label", "goblin 2", "", entity.GoblinEnemyTemplate.Description},
+		{"unique name", "rat", "", entity.CaveRatTemplate.Description},
+		{"nothing by that name", "dragon", "You don't see any dragon here.", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, _ := newTestGame(t)
+			placeCharacter(g, entity.NewEnemy(entity.GoblinEnemyTemplate), 8, 4)
+			placeCharacter(g, entity.NewEnemy(entity.GoblinEnemyTemplate), 3, 4)
+			placeCharacter(g, entity.NewEnemy(entity.CaveRatTemplate), 6, 2)
+			g.updateFieldOfView()
+
+			err := g.examine(tt.object)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("examine(%q) error = %v, want %q", tt.object, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("examine(%q) error = %v", tt.object, err)
+			}
+			if got := g.Room.LogView.String(); !strings.Contains(got, tt.want) {
+				t.Errorf("examine(%q) wrote %q, want it to contain %q", tt.object, got, tt.want)
+			}
+		})
+	}
+}
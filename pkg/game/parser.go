@@ -0,0 +1,245 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Command is a parsed player command, e.g. "use rusty key on door" has the
+// verb use, the object "rusty key", the preposition on and the target door.
+type Command struct {
+	Verb        string
+	Object      string
+	Preposition string
+	Target      string
+}
+
+// maxSuggestionDistance is how many typos a "did you mean" may fix
+const maxSuggestionDistance = 2
+
+var (
+	// shortcuts expand to a whole command
+	shortcuts = map[string]string{
+		"n":  "move north",
+		"s":  "move south",
+		"e":  "move east",
+		"w":  "move west",
+		"ne": "move northeast",
+		"nw": "move northwest",
+		"se": "move southeast",
+		"sw": "move southwest",
+		"i":  "inventory",
+		"l":  "look",
+		"x":  "examine",
+		"z":  "wait",
+		"q":  "quit",
+	}
+
+	// verbAliases map other words to a verb in ValidCommands
+	verbAliases = map[string]string{
+		"go":      InputActionMove,
+		"walk":    InputActionMove,
+		"step":    InputActionMove,
+		"hit":     InputActionAttack,
+		"fight":   InputActionAttack,
+		"kill":    InputActionAttack,
+		"strike":  InputActionAttack,
+		"get":     InputActionPick,
+		"take":    InputActionPick,
+		"grab":    InputActionPick,
+		"wield":   InputActionEquip,
+		"wear":    InputActionEquip,
+		"remove":  InputActionUnequip,
+		"quaff":   InputActionDrink,
+		"inv":     InputActionInventory,
+		"check":   InputActionExamine,
+		"inspect": InputActionExamine,
+		"x":       InputActionExamine,
+	}
+
+	// phrasalVerbs are two word verbs, the particle is dropped
+	phrasalVerbs = map[string]string{
+		"pick up":  InputActionPick,
+		"put down": InputActionDrop,
+		"look at":  InputActionExamine,
+		"take off": InputActionUnequip,
+		"put on":   InputActionEquip,
+	}
+
+	directionAliases = map[string]string{
+		"n":  DirectionNorth,
+		"s":  DirectionSouth,
+		"e":  DirectionEast,
+		"w":  DirectionWest,
+		"ne": DirectionNortheast,
+		"nw": DirectionNorthwest,
+		"se": DirectionSoutheast,
+		"sw": DirectionSouthwest,
+	}
+
+	prepositions = []string{"on", "with", "at", "to", "into", "in", "from"}
+
+	articles = []string{"the", "a", "an"}
+
+	// objectRequired lists verbs that make no sense without an object
+	objectRequired = map[string]string{
+		InputActionMove:    "Move where?",
+		InputActionAttack:  "Attack what?",
+		InputActionUse:     "Use what?",
+		InputActionExamine: "Examine what?",
+		InputActionOpen:    "Open what?",
+		InputActionClose:   "Close what?",
+		InputActionPick:    "Pick up what?",
+		InputActionDrop:    "Drop what?",
+		InputActionTalk:    "Talk to whom?",
+		InputActionRead:    "Read what?",
+		InputActionCast:    "Cast what?",
+		InputActionEquip:   "Equip what?",
+		InputActionUnequip: "Unequip what?",
+		InputActionDrink:   "Drink what?",
+		InputActionEat:     "Eat what?",
+		InputActionSave:    "Save to which slot?",
+		InputActionLoad:    "Load which slot?",
+	}
+)
+
+// ParseCommand turns free text into a command. Words before the first
+// known verb are ignored so "please go north" works.
+func ParseCommand(input string) (Command, error) {
+	words := strings.Fields(strings.ToLower(input))
+	if len(words) == 0 {
+		return Command{}, fmt.Errorf("Say something. Type help for a list of commands.")
+	}
+
+	if expansion, ok := shortcuts[words[0]]; ok && len(words) == 1 {
+		words = strings.Fields(expansion)
+	}
+
+	// a bare direction means moving that way
+	if isValidDirection(normalizeDirection(words[0])) && len(words) == 1 {
+		words = []string{InputActionMove, words[0]}
+	}
+
+	var verb string
+	var rest []string
+	for i, word := range words {
+		if i+1 < len(words) {
+			if v, ok := phrasalVerbs[word+" "+words[i+1]]; ok {
+				verb, rest = v, words[i+2:]
+				break
+			}
+		}
+		if v, ok := toVerb(word); ok {
+			verb, rest = v, words[i+1:]
+			break
+		}
+	}
+	if verb == "" {
+		return Command{}, unknownVerbError(words[0])
+	}
+
+	cmd := Command{Verb: verb}
+	object := rest
+	for i, word := range rest {
+		// a leading preposition belongs to the verb, "talk to goblin"
+		if i > 0 && slices.Contains(prepositions, word) {
+			object = rest[:i]
+			cmd.Preposition = word
+			cmd.Target = joinWords(rest[i+1:])
+			break
+		}
+	}
+	if len(object) > 0 && slices.Contains(prepositions, object[0]) {
+		object = object[1:]
+	}
+	cmd.Object = joinWords(object)
+
+	if cmd.Verb == InputActionMove || cmd.Verb == InputActionAttack {
+		cmd.Object = normalizeDirection(cmd.Object)
+	}
+
+	if question, ok := objectRequired[cmd.Verb]; ok && cmd.Object == "" {
+		return cmd, fmt.Errorf("%s", question)
+	}
+
+	if cmd.Preposition != "" && cmd.Target == "" {
+		return cmd, fmt.Errorf("%s %s what?", strings.ToUpper(cmd.Verb[:1])+cmd.Verb[1:], cmd.Preposition)
+	}
+
+	return cmd, nil
+}
+
+func toVerb(word string) (string, bool) {
+	if slices.Contains(ValidCommands, word) {
+		return word, true
+	}
+	verb, ok := verbAliases[word]
+	return verb, ok
+}
+
+func normalizeDirection(word string) string {
+	if direction, ok := directionAliases[word]; ok {
+		return direction
+	}
+	return word
+}
+
+// joinWords joins the words of an object, leaving out articles.
+func joinWords(words []string) string {
+	kept := make([]string, 0, len(words))
+	for _, word := range words {
+		if !slices.Contains(articles, word) {
+			kept = append(kept, word)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+func unknownVerbError(word string) error {
+	if suggestion := suggestCommand(word); suggestion != "" {
+		return fmt.Errorf("I don't know how to %q. Did you mean %q?", word, suggestion)
+	}
+	return fmt.Errorf("I don't know how to %q. Type help for a list of commands.", word)
+}
+
+// suggestCommand finds the known verb closest to a misspelled word.
+func suggestCommand(word string) string {
+	candidates := slices.Clone(ValidCommands)
+	for alias := range verbAliases {
+		candidates = append(candidates, alias)
+	}
+	slices.Sort(candidates)
+
+	best, bestDistance := "", maxSuggestionDistance+1
+	for _, candidate := range candidates {
+		if d := levenshtein(word, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// levenshtein counts the single character edits between two words.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
@@ -0,0 +1,191 @@
+package game
+
+import (
+	"fmt"
+
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/logging"
+)
+
+const (
+	// PerceptionRadius is how far enemies can see the player
+	PerceptionRadius = 7
+
+	// how far the sound of player actions carries
+	NoiseMove   = 3
+	NoiseAttack = 7
+
+	// how long a pack scatters after its leader dies
+	minFleeTurns = 6
+	maxFleeTurns = 12
+)
+
+// flankOffsets spread pack members around the player, consecutive members
+// take opposite sides
+var flankOffsets = [][2]int{
+	{-1, 0}, {1, 0}, {0, 1}, {0, -1},
+	{-1, 1}, {1, -1}, {1, 1}, {-1, -1},
+}
+
+// makeNoise records the loudest sound the player made this turn.
+func (g *Game) makeNoise(radius int) {
+	g.noise = max(g.noise, radius)
+}
+
+func (g *Game) canSeePlayer(enemy *entity.Character) bool {
+	return distance(enemy.X, enemy.Y, g.Player.X, g.Player.Y) <= PerceptionRadius &&
+		g.Room.HasLineOfSight(enemy.X, enemy.Y, g.Player.X, g.Player.Y)
+}
+
+func (g *Game) canHearPlayer(enemy *entity.Character) bool {
+	return g.noise > 0 && distance(enemy.X, enemy.Y, g.Player.X, g.Player.Y) <= float64(g.noise)
+}
+
+// perceive updates what the enemy knows about the player.
+func (g *Game) perceive(enemy *entity.Character) {
+	if enemy.AIState == entity.AIStateFleeing {
+		return
+	}
+
+	previous := enemy.AIState
+	switch {
+	case g.canSeePlayer(enemy):
+		enemy.AIState = entity.AIStateHunting
+		enemy.LastSeenX, enemy.LastSeenY = g.Player.X, g.Player.Y
+	case g.canHearPlayer(enemy) && previous != entity.AIStateHunting:
+		enemy.AIState = entity.AIStateAlerted
+		enemy.LastSeenX, enemy.LastSeenY = g.Player.X, g.Player.Y
+	case previous == entity.AIStateHunting:
+		// lost sight, search where the player was last seen
+		enemy.AIState = entity.AIStateAlerted
+	}
+
+	if enemy.AIState == previous {
+		return
+	}
+
+	g.Logger.LogMessage(logging.LogLevelDebug,
+		fmt.Sprintf("Enemy %s is now %s", enemy.Name, enemy.AIState))
+
+	if enemy.IsLeader && isIdle(previous) {
+		g.alertPack(enemy)
+	}
+}
+
+// alertPack has a leader call its pack to where it last saw the player.
+func (g *Game) alertPack(leader *entity.Character) {
+	g.Room.LogView.WriteString(fmt.Sprintf("%s shouts an alarm!\n", leader.Name))
+	for _, member := range g.packMembers(leader.PackID) {
+		if member == leader || !isIdle(member.AIState) {
+			continue
+		}
+		member.AIState = entity.AIStateAlerted
+		member.LastSeenX, member.LastSeenY = leader.LastSeenX, leader.LastSeenY
+	}
+}
+
+// breakPackMorale scatters a pack whose leader has fallen.
+func (g *Game) breakPackMorale(packID int) {
+	members := g.packMembers(packID)
+	if len(members) == 0 {
+		return
+	}
+
+	g.Room.LogView.WriteString("With their leader dead, the pack scatters in panic!\n")
+	for _, member := range members {
+		member.AIState = entity.AIStateFleeing
+		member.FleeTurns = minFleeTurns + g.Rand.Intn(maxFleeTurns-minFleeTurns+1)
+	}
+}
+
+// packMembers returns the living members of a pack, leader included.
+func (g *Game) packMembers(packID int) []*entity.Character {
+	if packID == 0 {
+		return nil
+	}
+
+	var members []*entity.Character
+	for _, enemy := range g.Enemies {
+		if enemy.PackID == packID && enemy.HP > 0 && !enemy.HasDied {
+			members = append(members, enemy)
+		}
+	}
+	return members
+}
+
+func (g *Game) packLeader(packID int) *entity.Character {
+	for _, member := range g.packMembers(packID) {
+		if member.IsLeader {
+			return member
+		}
+	}
+	return nil
+}
+
+// investigate heads for the last known position of the player and gives
+// up once there is nothing to find or no way to get there.
+func investigate(g *Game, enemy *entity.Character) {
+	if enemy.X == enemy.LastSeenX && enemy.Y == enemy.LastSeenY {
+		enemy.AIState = entity.AIStateIdle
+		return
+	}
+	if !moveTowards(g, enemy, enemy.LastSeenX, enemy.LastSeenY) {
+		enemy.AIState = entity.AIStateIdle
+	}
+}
+
+// scatter runs from the player until the panic wears off.
+func scatter(g *Game, enemy *entity.Character) {
+	enemy.FleeTurns--
+	if enemy.FleeTurns <= 0 {
+		enemy.AIState = entity.AIStateIdle
+		enemy.PackID = 0
+	}
+
+	if !moveAway(g, enemy, g.Player.X, g.Player.Y) && isAdjacent(enemy, g.Player) {
+		g.Room.AttackEntity(enemy, g.Player)
+	}
+}
+
+// idle lets passive behaviors carry on and sends everyone else home.
+func idle(g *Game, enemy *entity.Character) {
+	if enemy.Behavior == entity.BehaviorWander {
+		wander(g, enemy)
+		return
+	}
+
+	if enemy.X != enemy.HomeX || enemy.Y != enemy.HomeY {
+		moveTowards(g, enemy, enemy.HomeX, enemy.HomeY)
+	}
+}
+
+// flank moves a pack member to its own side of the player. It reports
+// false when the member should just use its usual behavior.
+func flank(g *Game, enemy *entity.Character) bool {
+	if enemy.IsLeader || isAdjacent(enemy, g.Player) || g.packLeader(enemy.PackID) == nil {
+		return false
+	}
+
+	var index int
+	for _, member := range g.packMembers(enemy.PackID) {
+		if member == enemy {
+			break
+		}
+		if !member.IsLeader {
+			index++
+		}
+	}
+
+	offset := flankOffsets[index%len(flankOffsets)]
+	x, y := g.Player.X+offset[0], g.Player.Y+offset[1]
+	if !g.Room.InBounds(x, y) || g.Room.Grid[x][y].Entity.ID != entity.ObjEmpty {
+		return false
+	}
+
+	moveTowards(g, enemy, x, y)
+	return true
+}
+
+func isIdle(state string) bool {
+	return state == "" || state == entity.AIStateIdle
+}
@@ -0,0 +1,250 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/room"
+)
+
+const (
+	SaveVersion   = 14
+	SaveDirectory = "saves"
+	SaveExtension = ".json"
+
+	// noEntity marks a grid cell that only holds terrain
+	noEntity = -1
+)
+
+var validSlot = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// SaveFile is the on-disk representation of a game. Characters are stored
+// once in Entities and referenced by index so that the aliasing between the
+// room grid, the player and the enemy list survives a round trip.
+type SaveFile struct {
+	Version  int
+	Seed     int64
+	Draws    int64
+	Turn     int
+	Elapsed  time.Duration
+	Stats    RunStats
+	Room     SavedRoom
+	Entities []*entity.Character
+	Player   int
+	Enemies  []int
+}
+
+type SavedRoom struct {
+	Level    int
+	Width    int
+	Height   int
+	CameraX  int
+	CameraY  int
+	Cells    [][]SavedCell
+	Explored [][]bool `json:",omitempty"`
+}
+
+type SavedCell struct {
+	Tile   entity.ID
+	Entity int
+	Items  []*entity.Item `json:",omitempty"`
+}
+
+func slotPath(slot string) (string, error) {
+	if !validSlot.MatchString(slot) {
+		return "", fmt.Errorf("invalid save slot %q", slot)
+	}
+	return filepath.Join(SaveDirectory, slot+SaveExtension), nil
+}
+
+// isTerrain reports whether a grid entity is plain terrain that carries no
+// state worth keeping beyond its ID.
+func isTerrain(c *entity.Character) bool {
+	return c == nil || c.ID == entity.ObjEmpty || c.ID == entity.ObjWall
+}
+
+func (g *Game) snapshot() *SaveFile {
+	save := &SaveFile{
+		Version: SaveVersion,
+		Seed:    g.Seed,
+		Draws:   g.RandSource.Draws,
+		Turn:    g.Turn,
+		Elapsed: time.Since(g.StartTime),
+		Stats:   g.Stats,
+	}
+
+	index := make(map[*entity.Character]int)
+	ref := func(c *entity.Character) int {
+		if i, ok := index[c]; ok {
+			return i
+		}
+		index[c] = len(save.Entities)
+		save.Entities = append(save.Entities, c)
+		return index[c]
+	}
+
+	save.Player = ref(g.Player)
+	for _, enemy := range g.Enemies {
+		save.Enemies = append(save.Enemies, ref(enemy))
+	}
+
+	r := g.Room
+	save.Room = SavedRoom{
+		Level:    r.Level,
+		Width:    r.Width,
+		Height:   r.Height,
+		CameraX:  r.CameraX,
+		CameraY:  r.CameraY,
+		Cells:    make([][]SavedCell, r.Width),
+		Explored: r.Explored,
+	}
+	for x := 0; x < r.Width; x++ {
+		save.Room.Cells[x] = make([]SavedCell, r.Height)
+		for y := 0; y < r.Height; y++ {
+			cell := SavedCell{Tile: entity.ObjEmpty, Entity: noEntity, Items: r.Grid[x][y].Items}
+			if e := r.Grid[x][y].Entity; e != nil {
+				cell.Tile = e.ID
+				if !isTerrain(e) {
+					cell.Entity = ref(e)
+				}
+			}
+			save.Room.Cells[x][y] = cell
+		}
+	}
+
+	return save
+}
+
+func (g *Game) restore(save *SaveFile) error {
+	if save.Version != SaveVersion {
+		return fmt.Errorf("unsupported save version %d", save.Version)
+	}
+
+	entityAt := func(i int) (*entity.Character, error) {
+		if i < 0 || i >= len(save.Entities) || save.Entities[i] == nil {
+			return nil, fmt.Errorf("corrupt save: bad entity reference %d", i)
+		}
+		return save.Entities[i], nil
+	}
+
+	player, err := entityAt(save.Player)
+	if err != nil {
+		return err
+	}
+
+	enemies := make([]*entity.Character, 0, len(save.Enemies))
+	for _, i := range save.Enemies {
+		enemy, err := entityAt(i)
+		if err != nil {
+			return err
+		}
+		enemies = append(enemies, enemy)
+	}
+
+	sr := save.Room
+	if sr.Width <= 0 || sr.Height <= 0 || len(sr.Cells) != sr.Width {
+		return fmt.Errorf("corrupt save: bad room dimensions")
+	}
+
+	// replay the draws made before saving so the game continues with the
+	// same rolls it would have had
+	source := NewSource(save.Seed)
+	source.skip(save.Draws)
+	rng := rand.New(source)
+
+	r := &room.Room{
+		Rand:     rng,
+		Level:    sr.Level,
+		Width:    sr.Width,
+		Height:   sr.Height,
+		CameraX:  sr.CameraX,
+		CameraY:  sr.CameraY,
+		Grid:     make([][]*room.Coordinate, sr.Width),
+		Explored: sr.Explored,
+	}
+	for x := 0; x < sr.Width; x++ {
+		if len(sr.Cells[x]) != sr.Height {
+			return fmt.Errorf("corrupt save: bad room dimensions")
+		}
+		r.Grid[x] = make([]*room.Coordinate, sr.Height)
+		for y := 0; y < sr.Height; y++ {
+			cell := sr.Cells[x][y]
+			e := &entity.Character{ID: cell.Tile}
+			if cell.Entity != noEntity {
+				if e, err = entityAt(cell.Entity); err != nil {
+					return err
+				}
+			}
+			r.Grid[x][y] = &room.Coordinate{X: x, Y: y, Entity: e, Items: cell.Items}
+		}
+	}
+
+	if r.Explored != nil && (len(r.Explored) != r.Width || len(r.Explored[0]) != r.Height) {
+		return fmt.Errorf("corrupt save: bad explored map")
+	}
+
+	g.Room = r
+	g.Room.OnAttack = g.recordAttack
+	g.Player = player
+	g.Enemies = enemies
+	g.Turn = save.Turn
+	g.Stats = save.Stats
+	g.Seed = save.Seed
+	g.Rand = rng
+	g.RandSource = source
+	g.StartTime = time.Now().Add(-save.Elapsed)
+
+	return nil
+}
+
+// Save writes the full game state to the given slot.
+func (g *Game) Save(slot string) error {
+	path, err := slotPath(slot)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(g.snapshot(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode save: %w", err)
+	}
+
+	if err := os.MkdirAll(SaveDirectory, 0755); err != nil {
+		return fmt.Errorf("failed to create save directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write save: %w", err)
+	}
+
+	return nil
+}
+
+// Load replaces the current game state with the one stored in the given slot.
+func (g *Game) Load(slot string) error {
+	path, err := slotPath(slot)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no save found in slot %s", slot)
+		}
+		return fmt.Errorf("failed to read save: %w", err)
+	}
+
+	var save SaveFile
+	if err := json.Unmarshal(data, &save); err != nil {
+		return fmt.Errorf("failed to decode save: %w", err)
+	}
+
+	return g.restore(&save)
+}
@@ -0,0 +1,60 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+
+	"bitcrawler/pkg/entity"
+)
+
+func TestSaveRoundTrip(t *testing.T) {
+	g, _ := newTestGame(t)
+	placeCharacter(g, entity.NewEnemy(entity.GoblinEnemyTemplate), 8, 4)
+	placeCharacter(g, entity.NewEnemy(entity.CaveRatTemplate), 3, 2)
+	g.Turn = 12
+	for i := 0; i < 25; i++ {
+		g.Rand.Intn(100)
+	}
+
+	data, err := json.Marshal(g.snapshot())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var save SaveFile
+	if err := json.Unmarshal(data, &save); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, _ := newTestGame(t)
+	if err := loaded.restore(&save); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := loaded.Room.Grid[loaded.Player.X][loaded.Player.Y].Entity; got != loaded.Player {
+		t.Errorf("grid holds %v at the player's tile, want the player", got)
+	}
+	if len(loaded.Enemies) != len(g.Enemies) {
+		t.Fatalf("loaded %d enemies, want %d", len(loaded.Enemies), len(g.Enemies))
+	}
+	for i, enemy := range loaded.Enemies {
+		if got := loaded.Room.Grid[enemy.X][enemy.Y].Entity; got != enemy {
+			t.Errorf("grid holds %v at enemy %d's tile, want the enemy", got, i)
+		}
+		if enemy.Name != g.Enemies[i].Name || enemy.X != g.Enemies[i].X || enemy.Y != g.Enemies[i].Y {
+			t.Errorf("enemy %d is %s at (%d, %d), want %s at (%d, %d)", i,
+				enemy.Name, enemy.X, enemy.Y, g.Enemies[i].Name, g.Enemies[i].X, g.Enemies[i].Y)
+		}
+	}
+
+	if loaded.Turn != g.Turn {
+		t.Errorf("turn = %d, want %d", loaded.Turn, g.Turn)
+	}
+	if loaded.RandSource.Draws != g.RandSource.Draws {
+		t.Errorf("draws = %d, want %d", loaded.RandSource.Draws, g.RandSource.Draws)
+	}
+	for i := 0; i < 10; i++ {
+		if got, want := loaded.Rand.Intn(1000), g.Rand.Intn(1000); got != want {
+			t.Fatalf("draw %d after loading = %d, want %d", i, got, want)
+		}
+	}
+}
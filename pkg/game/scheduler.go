@@ -0,0 +1,157 @@
+package game
+
+import (
+	"fmt"
+
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/logging"
+)
+
+const (
+	// ActionCost is the energy a normal action takes. A character with
+	// normal speed gains this much energy every tick of the game clock.
+	ActionCost = 100
+
+	// RestTurns is how long rest lasts when no duration is given
+	RestTurns = 10
+)
+
+// freeActions do not take any game time
+var freeActions = []string{
+	InputActionSave,
+	InputActionLoad,
+	InputActionQuit,
+	InputActionExit,
+	InputActionHelp,
+	InputActionLook,
+	InputActionExamine,
+	InputActionInventory,
+	InputActionStatus,
+	InputActionStats,
+	InputActionQuests,
+	InputActionJournal,
+	InputActionScores,
+}
+
+// actionCost returns the energy the player spends on an action.
+func actionCost(action string) int {
+	for _, free := range freeActions {
+		if action == free {
+			return 0
+		}
+	}
+	return ActionCost
+}
+
+// advanceTime runs the game clock until the player has enough energy to
+// act. Enemies act whenever they have gathered enough energy, so fast
+// enemies can act several times between player turns and slow ones skip.
+func (g *Game) advanceTime() {
+	for g.Player.Energy < ActionCost && !g.GameOver {
+		g.Turn++
+		g.Logger.LogMessage(logging.LogLevelDebug, fmt.Sprintf("Game turn %d", g.Turn))
+
+		if !g.checkPlayerAlive(g.tickEffects(g.Player)) {
+			return
+		}
+		g.tickAbilities(g.Player)
+
+		g.Player.Energy += g.Player.EffectiveSpeed()
+		for _, enemy := range g.Enemies {
+			if !g.checkEnemyAlive(enemy) {
+				continue
+			}
+			g.tickAbilities(enemy)
+
+			enemy.Energy += enemy.EffectiveSpeed()
+			for enemy.Energy >= ActionCost && g.checkEnemyAlive(enemy) {
+				enemy.Energy -= ActionCost
+				if g.stunned(enemy) {
+					continue
+				}
+				g.enemyTurn(enemy)
+				if !g.checkPlayerAlive(killedBy(enemy.Name)) {
+					return
+				}
+			}
+
+			// effects the player put on the enemy last until it has acted
+			// under them for their full duration
+			g.tickEffects(enemy)
+			g.checkEnemyAlive(enemy)
+		}
+
+		// enemies only hear what the player did on the first tick
+		g.noise = 0
+	}
+}
+
+// checkEnemyAlive handles enemies that died since they last acted and
+// reports whether the enemy can still take turns.
+func (g *Game) checkEnemyAlive(enemy *entity.Character) bool {
+	if enemy.HP > 0 {
+		return true
+	}
+
+	if !enemy.HasDied {
+		g.Logger.LogMessage(logging.LogLevelDebug,
+			fmt.Sprintf("Enemy %s has died", enemy.Name))
+		enemy.HasDied = true
+		g.Stats.recordKill(enemy.Name)
+		g.Room.LogView.WriteString(enemy.DeathMessage)
+		if enemy.IsLeader {
+			g.breakPackMorale(enemy.PackID)
+		}
+	}
+
+	return false
+}
+
+// startRest begins resting for the given number of turns.
+func (g *Game) startRest(turns string) error {
+	g.resting = RestTurns
+	if turns != "" {
+		if _, err := fmt.Sscan(turns, &g.resting); err != nil || g.resting <= 0 {
+			g.resting = 0
+			return fmt.Errorf("rest for how long?")
+		}
+	}
+
+	if err := g.checkRestInterrupted(); err != nil {
+		return err
+	}
+
+	g.Room.LogView.WriteString(fmt.Sprintf("%s settles down to rest.\n", g.Player.Name))
+	return g.rest()
+}
+
+func (g *Game) checkRestInterrupted() error {
+	for _, enemy := range g.Enemies {
+		if !enemy.HasDied && g.Room.IsVisible(enemy.X, enemy.Y) {
+			g.resting = 0
+			return fmt.Errorf("you cannot rest with %s nearby", enemy.Name)
+		}
+	}
+	return nil
+}
+
+// rest spends one turn recovering. Resting stops when it runs out, the
+// player is fully healed or an enemy comes into view.
+func (g *Game) rest() error {
+	if err := g.checkRestInterrupted(); err != nil {
+		return err
+	}
+
+	if g.Player.MaxHP > 0 && g.Player.HP < g.Player.MaxHP {
+		g.Player.HP++
+	}
+
+	g.resting--
+	if g.resting <= 0 || (g.Player.MaxHP > 0 && g.Player.HP >= g.Player.MaxHP) {
+		g.resting = 0
+		g.Room.LogView.WriteString(fmt.Sprintf("%s finishes resting.\n", g.Player.Name))
+	}
+
+	g.actionCost = ActionCost
+	return nil
+}
@@ -0,0 +1,204 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"bitcrawler/pkg/combat"
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/logging"
+)
+
+const (
+	HighScoreFile = "scores.json"
+	MaxHighScores = 10
+
+	ScorePerDepth     = 100
+	ScorePerKill      = 20
+	ScoreVictoryBonus = 1000
+
+	outcomeEscaped = "escaped the dungeon"
+)
+
+// RunStats is what the player achieved during a run.
+type RunStats struct {
+	Kills       map[string]int
+	DamageDealt int
+	DamageTaken int
+	Depth       int
+}
+
+// HighScore is one finished run in the high score table.
+type HighScore struct {
+	Name    string
+	Score   int
+	Depth   int
+	Kills   int
+	Turns   int
+	Gold    int
+	Outcome string
+	Seed    int64
+	Date    time.Time
+}
+
+func (s *RunStats) recordKill(name string) {
+	if s.Kills == nil {
+		s.Kills = make(map[string]int)
+	}
+	s.Kills[name]++
+}
+
+// TotalKills counts the enemies killed of every type.
+func (s RunStats) TotalKills() int {
+	total := 0
+	for _, kills := range s.Kills {
+		total += kills
+	}
+	return total
+}
+
+// describeKills lists kills by enemy type, e.g. "2 Goblin, 1 Cave Rat".
+func (s RunStats) describeKills() string {
+	names := make([]string, 0, len(s.Kills))
+	for name := range s.Kills {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	kills := make([]string, 0, len(names))
+	for _, name := range names {
+		kills = append(kills, fmt.Sprintf("%d %s", s.Kills[name], name))
+	}
+	return strings.Join(kills, ", ")
+}
+
+// recordAttack keeps track of the damage the player deals and takes.
+func (g *Game) recordAttack(attacker, defender *entity.Character, result combat.Result) {
+	if attacker == g.Player {
+		g.Stats.DamageDealt += result.Damage
+	}
+	if defender == g.Player {
+		g.Stats.DamageTaken += result.Damage
+	}
+}
+
+// gold is the value of the treasure the player carries.
+func (g *Game) gold() int {
+	gold := 0
+	for _, item := range g.Player.Inventory {
+		if item.Kind == entity.ItemTreasure {
+			gold += item.Value
+		}
+	}
+	return gold
+}
+
+func (g *Game) score(escaped bool) int {
+	score := g.Stats.Depth*ScorePerDepth + g.Stats.TotalKills()*ScorePerKill + g.gold() + g.Stats.DamageDealt/2
+	if escaped {
+		score += ScoreVictoryBonus
+	}
+	return score
+}
+
+// recordRun adds the finished run to the high score table and shows it.
+func (g *Game) recordRun(outcome string) {
+	entry := HighScore{
+		Name:    g.Player.Name,
+		Score:   g.score(outcome == outcomeEscaped),
+		Depth:   g.Stats.Depth,
+		Kills:   g.Stats.TotalKills(),
+		Turns:   g.Turn,
+		Gold:    g.gold(),
+		Outcome: outcome,
+		Seed:    g.Seed,
+		Date:    time.Now(),
+	}
+
+	scores, err := loadHighScores(HighScoreFile)
+	if err != nil {
+		g.Logger.LogMessage(logging.LogLevelError, err.Error())
+		return
+	}
+
+	scores, rank := addHighScore(scores, entry)
+	if rank > 0 {
+		if err := saveHighScores(HighScoreFile, scores); err != nil {
+			g.Logger.LogMessage(logging.LogLevelError, err.Error())
+		}
+		g.show(fmt.Sprintf("New high score! You placed #%d.", rank))
+	}
+	g.show(formatHighScores(scores))
+}
+
+func (g *Game) showHighScores() error {
+	scores, err := loadHighScores(HighScoreFile)
+	if err != nil {
+		return err
+	}
+	g.Room.LogView.WriteString(formatHighScores(scores) + "\n")
+	return nil
+}
+
+func loadHighScores(path string) ([]HighScore, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read high scores: %w", err)
+	}
+
+	var scores []HighScore
+	if err := json.Unmarshal(data, &scores); err != nil {
+		return nil, fmt.Errorf("failed to decode high scores: %w", err)
+	}
+	return scores, nil
+}
+
+func saveHighScores(path string, scores []HighScore) error {
+	data, err := json.MarshalIndent(scores, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode high scores: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write high scores: %w", err)
+	}
+	return nil
+}
+
+// addHighScore places an entry in the table, behind earlier runs with the
+// same score, and returns its rank or 0 when it did not make the cut.
+func addHighScore(scores []HighScore, entry HighScore) ([]HighScore, int) {
+	i := 0
+	for i < len(scores) && scores[i].Score >= entry.Score {
+		i++
+	}
+	if i >= MaxHighScores {
+		return scores, 0
+	}
+
+	scores = slices.Insert(scores, i, entry)
+	if len(scores) > MaxHighScores {
+		scores = scores[:MaxHighScores]
+	}
+	return scores, i + 1
+}
+
+func formatHighScores(scores []HighScore) string {
+	if len(scores) == 0 {
+		return "No high scores yet."
+	}
+
+	lines := []string{"High scores:"}
+	for i, s := range scores {
+		lines = append(lines, fmt.Sprintf("%2d. %6d  %s, %s on level %d after %d turns (%s)",
+			i+1, s.Score, s.Name, s.Outcome, s.Depth, s.Turns, s.Date.Format(time.DateOnly)))
+	}
+	return strings.Join(lines, "\n")
+}
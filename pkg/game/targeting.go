@@ -0,0 +1,139 @@
+package game
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"bitcrawler/pkg/entity"
+)
+
+const targetNearest = "nearest"
+
+// noTargetError is returned when no enemy in view matches a name, as opposed
+// to several matching, so callers can look for something else by that name.
+type noTargetError struct {
+	message string
+}
+
+func (e *noTargetError) Error() string {
+	return e.message
+}
+
+// target is an enemy the player can refer to by label, e.g. "goblin 2"
+// when more than one goblin is in view.
+type target struct {
+	Enemy *entity.Character
+	Label string
+}
+
+// visibleTargets lists the living enemies in view, nearest first. Enemies
+// sharing a name are numbered in that order.
+func (g *Game) visibleTargets() []target {
+	var visible []*entity.Character
+	for _, enemy := range g.Enemies {
+		if !enemy.HasDied && enemy.HP > 0 && g.Room.IsVisible(enemy.X, enemy.Y) {
+			visible = append(visible, enemy)
+		}
+	}
+
+	slices.SortStableFunc(visible, func(a, b *entity.Character) int {
+		return cmp.Compare(
+			distance(a.X, a.Y, g.Player.X, g.Player.Y),
+			distance(b.X, b.Y, g.Player.X, g.Player.Y))
+	})
+
+	counts := make(map[string]int)
+	for _, enemy := range visible {
+		counts[enemy.Name]++
+	}
+
+	seen := make(map[string]int)
+	targets := make([]target, 0, len(visible))
+	for _, enemy := range visible {
+		label := enemy.Name
+		if counts[enemy.Name] > 1 {
+			seen[enemy.Name]++
+			label = fmt.Sprintf("%s %d", enemy.Name, seen[enemy.Name])
+		}
+		targets = append(targets, target{Enemy: enemy, Label: label})
+	}
+	return targets
+}
+
+// resolveTarget finds the enemy the player means by name, label or
+// "nearest". A name shared by several enemies is only accepted when exactly
+// one of them is within reach.
+func (g *Game) resolveTarget(name string) (target, error) {
+	targets := g.visibleTargets()
+	if len(targets) == 0 {
+		return target{}, &noTargetError{"There is nothing in sight."}
+	}
+
+	if name == targetNearest {
+		return targets[0], nil
+	}
+
+	// numbers count enemies of that name from the nearest, so "goblin 1"
+	// still works once the other goblins are gone
+	if fields := strings.Fields(name); len(fields) > 1 {
+		if n, err := strconv.Atoi(fields[len(fields)-1]); err == nil {
+			base := strings.Join(fields[:len(fields)-1], " ")
+			matches := matchTargets(targets, func(t target) bool { return strings.EqualFold(t.Enemy.Name, base) })
+			if n < 1 || n > len(matches) {
+				return target{}, &noTargetError{fmt.Sprintf("You don't see %s here.", name)}
+			}
+			return matches[n-1], nil
+		}
+	}
+
+	matches := matchTargets(targets, func(t target) bool { return strings.EqualFold(t.Enemy.Name, name) })
+	if len(matches) == 0 {
+		matches = matchTargets(targets, func(t target) bool { return entity.MatchesName(t.Enemy.Name, name) })
+	}
+
+	switch len(matches) {
+	case 0:
+		return target{}, &noTargetError{fmt.Sprintf("You don't see any %s here.", name)}
+	case 1:
+		return matches[0], nil
+	}
+
+	inReach := matchTargets(matches, func(t target) bool { return isAdjacent(g.Player, t.Enemy) })
+	if len(inReach) == 1 {
+		return inReach[0], nil
+	}
+
+	labels := make([]string, len(matches))
+	for i, t := range matches {
+		labels[i] = strings.ToLower(t.Label)
+	}
+	return target{}, fmt.Errorf("Which one? %s.", strings.Join(labels, ", "))
+}
+
+func matchTargets(targets []target, match func(target) bool) []target {
+	var matches []target
+	for _, t := range targets {
+		if match(t) {
+			matches = append(matches, t)
+		}
+	}
+	return matches
+}
+
+// attackTarget attacks an enemy by name or "nearest" if it is in reach.
+func (g *Game) attackTarget(name string) error {
+	t, err := g.resolveTarget(name)
+	if err != nil {
+		return err
+	}
+
+	if !isAdjacent(g.Player, t.Enemy) {
+		return fmt.Errorf("%s is out of reach.", t.Label)
+	}
+
+	g.Room.AttackEntity(g.Player, t.Enemy)
+	return nil
+}
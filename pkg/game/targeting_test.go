@@ -0,0 +1,19 @@
+package game
+
+import (
+	"testing"
+
+	"bitcrawler/pkg/entity"
+)
+
+func TestAttackTargetOutOfReach(t *testing.T) {
+	g, _ := newTestGame(t)
+	placeCharacter(g, entity.NewEnemy(entity.GoblinEnemyTemplate), 9, 4)
+	placeCharacter(g, entity.NewEnemy(entity.GoblinEnemyTemplate), 3, 4)
+	g.updateFieldOfView()
+
+	err := g.attackTarget("goblin 1")
+	if want := "Goblin 1 is out of reach."; err == nil || err.Error() != want {
+		t.Errorf("attackTarget error = %v, want %q", err, want)
+	}
+}
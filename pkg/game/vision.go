@@ -0,0 +1,146 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+
+	"bitcrawler/pkg/logging"
+	"bitcrawler/pkg/render"
+	"bitcrawler/pkg/terminal"
+)
+
+// updateCamera sizes the viewport to what the terminal has left next to the
+// side panel and above the messages, and centers it on the player. Every
+// tile is drawn two characters wide. Asking the terminal for its size runs
+// stty, so the answer is kept until the next level.
+func (g *Game) updateCamera() {
+	if g.screenColumns == 0 {
+		g.screenColumns, g.screenRows = terminal.Size()
+	}
+	g.Room.SetViewport((g.screenColumns-render.SidePanelWidth-3)/2, g.screenRows-render.ChromeRows)
+	g.Room.CenterCamera(g.Player.X, g.Player.Y)
+}
+
+// updateFieldOfView recomputes what the player can see and announces
+// enemies the first time they come into view.
+func (g *Game) updateFieldOfView() {
+	g.Room.ComputeFOV(g.Player.X, g.Player.Y, SightRadius)
+
+	for _, enemy := range g.Enemies {
+		if enemy.HasBeenSeen || enemy.HasDied || !g.Room.IsVisible(enemy.X, enemy.Y) {
+			continue
+		}
+
+		enemy.HasBeenSeen = true
+		g.Logger.LogMessage(logging.LogLevelDebug,
+			fmt.Sprintf("Enemy %s came into view at (%d, %d)", enemy.Name, enemy.X, enemy.Y))
+		if enemy.SeenMessage != "" {
+			g.Room.LogView.WriteString(enemy.SeenMessage + "\n")
+		}
+	}
+}
+
+// render draws the current view of the room along with the message
+// history gathered so far.
+func (g *Game) render(prompt string) {
+	g.collectMessages()
+
+	frame := render.Frame{
+		Map:           g.Room.View(),
+		Status:        g.status(),
+		Enemies:       g.enemiesInView(),
+		Messages:      g.Messages[:len(g.Messages)-g.messageScroll],
+		MessageScroll: g.messageScroll,
+		Prompt:        prompt,
+	}
+
+	if err := g.Renderer.Render(frame); err != nil {
+		g.Logger.LogMessage(logging.LogLevelError, "Failed to render: "+err.Error())
+		return
+	}
+	g.Logger.LogMessage(logging.LogLevelDebug, "Room drawn")
+}
+
+// collectMessages moves this turn's messages into the history. New
+// messages scroll the pane back to the bottom.
+func (g *Game) collectMessages() {
+	messages := splitMessages(g.Room.LogView.String())
+	g.Room.LogView.Reset()
+	if len(messages) == 0 {
+		return
+	}
+
+	g.Messages = append(g.Messages, messages...)
+	if len(g.Messages) > MaxMessageHistory {
+		g.Messages = g.Messages[len(g.Messages)-MaxMessageHistory:]
+	}
+	g.messageScroll = 0
+}
+
+// scrollMessages moves the message pane through the history.
+func (g *Game) scrollMessages(direction string) error {
+	switch direction {
+	case DirectionUp, "":
+		g.messageScroll += render.MessagePaneHeight
+	case DirectionDown:
+		g.messageScroll -= render.MessagePaneHeight
+	default:
+		return fmt.Errorf("scroll the journal up or down")
+	}
+
+	g.messageScroll = max(0, min(g.messageScroll, len(g.Messages)-render.MessagePaneHeight))
+	return nil
+}
+
+func (g *Game) status() render.Status {
+	status := render.Status{
+		Name:  g.Player.Name,
+		HP:    g.Player.HP,
+		MaxHP: g.Player.MaxHP,
+		Level: g.Room.Level,
+		Turn:  g.Turn,
+
+		Mana:       g.Player.Mana,
+		MaxMana:    g.Player.MaxMana,
+		Stamina:    g.Player.Stamina,
+		MaxStamina: g.Player.MaxStamina,
+
+		Effects: g.Player.StatusNames(),
+	}
+	for _, ability := range g.Player.Abilities {
+		name := ability.Name
+		if turns := g.Player.Cooldowns[ability.Name]; turns > 0 {
+			name += fmt.Sprintf(" (%d)", turns)
+		}
+		status.Abilities = append(status.Abilities, name)
+	}
+	return status
+}
+
+// enemiesInView lists the living enemies the player can see, nearest first.
+func (g *Game) enemiesInView() []render.EnemyInfo {
+	targets := g.visibleTargets()
+	enemies := make([]render.EnemyInfo, 0, len(targets))
+	for _, t := range targets {
+		enemies = append(enemies, render.EnemyInfo{
+			Glyph:  t.Enemy.Visual,
+			Name:   t.Label,
+			Health: strings.Join(append([]string{t.Enemy.HealthDescription()}, t.Enemy.StatusNames()...), ", "),
+		})
+	}
+	return enemies
+}
+
+func (g *Game) show(text string) {
+	if err := g.Renderer.Show(text); err != nil {
+		g.Logger.LogMessage(logging.LogLevelError, "Failed to render: "+err.Error())
+	}
+}
+
+func splitMessages(log string) []string {
+	log = strings.TrimRight(log, "\n")
+	if log == "" {
+		return nil
+	}
+	return strings.Split(log, "\n")
+}
@@ -1 +1,38 @@
 package gear
+
+import "bitcrawler/pkg/entity"
+
+var (
+	ArmorLeather = entity.Item{
+		Name:        "Leather Armor",
+		Description: "Supple boiled leather that turns aside glancing blows",
+		Kind:        entity.ItemArmor,
+		Slot:        entity.SlotArmor,
+		Visual:      '[',
+		Effect: entity.Effect{
+			Defense: 2,
+		},
+	}
+	ArmorChainMail = entity.Item{
+		Name:        "Chain Mail",
+		Description: "Interlocking iron rings, heavy but dependable",
+		Kind:        entity.ItemArmor,
+		Slot:        entity.SlotArmor,
+		Visual:      '[',
+		Effect: entity.Effect{
+			Defense: 4,
+		},
+	}
+	ArmorWoodenShield = entity.Item{
+		Name:        "Wooden Shield",
+		Description: "A round shield of oak planks bound with iron",
+		Kind:        entity.ItemArmor,
+		Slot:        entity.SlotShield,
+		Visual:      ')',
+		Effect: entity.Effect{
+			Defense: 2,
+		},
+	}
+
+	Armor = []entity.Item{ArmorLeather, ArmorChainMail, ArmorWoodenShield}
+)
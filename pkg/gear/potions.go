@@ -0,0 +1,39 @@
+package gear
+
+import "bitcrawler/pkg/entity"
+
+var (
+	PotionHealing = entity.Item{
+		Name:        "Healing Draught",
+		Description: "A thick red draught that closes wounds",
+		Kind:        entity.ItemConsumable,
+		Visual:      '!',
+		Effect: entity.Effect{
+			HP: 25,
+		},
+	}
+	PotionRegeneration = entity.Item{
+		Name:        "Regeneration Potion",
+		Description: "A green tonic that slowly knits flesh together",
+		Kind:        entity.ItemConsumable,
+		Visual:      '!',
+		Effect: entity.Effect{
+			HP:       2,
+			Status:   entity.StatusRegeneration,
+			Duration: 10,
+		},
+	}
+	PotionHaste = entity.Item{
+		Name:        "Haste Potion",
+		Description: "A fizzing blue potion that quickens the blood",
+		Kind:        entity.ItemConsumable,
+		Visual:      '!',
+		Effect: entity.Effect{
+			Speed:    50,
+			Status:   entity.StatusHaste,
+			Duration: 15,
+		},
+	}
+
+	Potions = []entity.Item{PotionHealing, PotionRegeneration, PotionHaste}
+)
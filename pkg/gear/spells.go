@@ -0,0 +1,59 @@
+package gear
+
+import "bitcrawler/pkg/entity"
+
+var (
+	SpellFirebolt = entity.Ability{
+		Name:        "Firebolt",
+		Description: "Hurls a streak of flame at the first foe in its path",
+		Shape:       entity.ShapeBolt,
+		Range:       6,
+		Power:       12,
+		DamageType:  entity.DamageFire,
+		Resource:    entity.ResourceMana,
+		Cost:        5,
+		Cooldown:    2,
+	}
+	SpellFrostNova = entity.Ability{
+		Name:        "Frost Nova",
+		Description: "A ring of frost bursts out and freezes everything nearby in place",
+		Shape:       entity.ShapeBurst,
+		Range:       2,
+		Power:       6,
+		DamageType:  entity.DamageCold,
+		Resource:    entity.ResourceMana,
+		Cost:        8,
+		Cooldown:    8,
+		Effect: entity.Effect{
+			Status:   entity.StatusStun,
+			Duration: 2,
+		},
+	}
+	SpellMend = entity.Ability{
+		Name:        "Mend",
+		Description: "Slowly closes your wounds",
+		Shape:       entity.ShapeSelf,
+		Resource:    entity.ResourceMana,
+		Cost:        6,
+		Cooldown:    12,
+		Effect: entity.Effect{
+			HP:       3,
+			Status:   entity.StatusRegeneration,
+			Duration: 6,
+		},
+	}
+	SkillCleave = entity.Ability{
+		Name:        "Cleave",
+		Description: "A wide swing that hits every foe in front of you",
+		Shape:       entity.ShapeCone,
+		Range:       2,
+		Power:       9,
+		DamageType:  entity.DamagePhysical,
+		Resource:    entity.ResourceStamina,
+		Cost:        6,
+		Cooldown:    3,
+	}
+
+	// Spells are the active abilities a new player starts with
+	Spells = []entity.Ability{SpellFirebolt, SpellFrostNova, SpellMend, SkillCleave}
+)
@@ -0,0 +1,20 @@
+package gear
+
+import "bitcrawler/pkg/entity"
+
+var (
+	ItemGoldPouch = entity.Item{
+		Name:        "Gold Pouch",
+		Description: "A small leather pouch heavy with coins",
+		Kind:        entity.ItemTreasure,
+		Visual:      '$',
+		Value:       25,
+	}
+	ItemGemstone = entity.Item{
+		Name:        "Gemstone",
+		Description: "A rough red gem that glints in the torchlight",
+		Kind:        entity.ItemTreasure,
+		Visual:      '*',
+		Value:       60,
+	}
+)
@@ -1 +1,52 @@
 package gear
+
+import "bitcrawler/pkg/entity"
+
+var (
+	WeaponDagger = entity.Item{
+		Name:        "Dagger",
+		Description: "A short blade, quick but light",
+		Kind:        entity.ItemWeapon,
+		Slot:        entity.SlotWeapon,
+		Visual:      '|',
+		Effect: entity.Effect{
+			Attack: 2,
+		},
+	}
+	WeaponRustySword = entity.Item{
+		Name:        "Rusty Sword",
+		Description: "A pitted old sword that still holds an edge",
+		Kind:        entity.ItemWeapon,
+		Slot:        entity.SlotWeapon,
+		Visual:      '/',
+		Effect: entity.Effect{
+			Attack: 3,
+		},
+	}
+	WeaponShortSword = entity.Item{
+		Name:        "Short Sword",
+		Description: "A well balanced blade of good steel",
+		Kind:        entity.ItemWeapon,
+		Slot:        entity.SlotWeapon,
+		Visual:      '/',
+		Effect: entity.Effect{
+			Attack: 5,
+		},
+	}
+	WeaponWarAxe = entity.Item{
+		Name:        "War Axe",
+		Description: "A heavy axe that hits hard but leaves you open",
+		Kind:        entity.ItemWeapon,
+		Slot:        entity.SlotWeapon,
+		Visual:      'P',
+		Effect: entity.Effect{
+			Attack:  8,
+			Defense: -2,
+		},
+		Inflicts: []entity.Effect{
+			{HP: -1, Status: entity.StatusBleeding, Duration: 4, Chance: 25},
+		},
+	}
+
+	Weapons = []entity.Item{WeaponDagger, WeaponRustySword, WeaponShortSword, WeaponWarAxe}
+)
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/room"
+)
+
+const bspMinLeaf = 8
+
+// binarySpacePartition recursively splits the map into leaves, places a room
+// in each leaf and connects sibling leaves with corridors.
+func binarySpacePartition(r *room.Room) {
+	fill(r, entity.ObjWall)
+	split(r, rect{x: 1, y: 1, w: r.Width - 2, h: r.Height - 2})
+}
+
+// split carves rooms inside the area and returns a point on the carved floor
+// that corridors from the parent can connect to.
+func split(r *room.Room, area rect) (int, int) {
+	splitVertical := area.w > area.h
+	if area.w >= bspMinLeaf*2 && area.h >= bspMinLeaf*2 {
+		splitVertical = r.Rand.Intn(2) == 0
+	}
+
+	size := area.h
+	if splitVertical {
+		size = area.w
+	}
+
+	// leaves too small to split get a room of their own
+	if size < bspMinLeaf*2 {
+		w := roomMinSize + r.Rand.Intn(max(1, area.w-roomMinSize-1))
+		h := roomMinSize + r.Rand.Intn(max(1, area.h-roomMinSize-1))
+		w, h = min(w, area.w), min(h, area.h)
+		leaf := rect{
+			x: area.x + r.Rand.Intn(area.w-w+1),
+			y: area.y + r.Rand.Intn(area.h-h+1),
+			w: w,
+			h: h,
+		}
+		carveRect(r, leaf)
+		return leaf.center()
+	}
+
+	cut := bspMinLeaf + r.Rand.Intn(size-bspMinLeaf*2+1)
+	var first, second rect
+	if splitVertical {
+		first = rect{x: area.x, y: area.y, w: cut - 1, h: area.h}
+		second = rect{x: area.x + cut, y: area.y, w: area.w - cut, h: area.h}
+	} else {
+		first = rect{x: area.x, y: area.y, w: area.w, h: cut - 1}
+		second = rect{x: area.x, y: area.y + cut, w: area.w, h: area.h - cut}
+	}
+
+	x1, y1 := split(r, first)
+	x2, y2 := split(r, second)
+	carveCorridor(r, x1, y1, x2, y2, splitVertical)
+
+	return x1, y1
+}
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/room"
+)
+
+const (
+	AlgorithmSingle = "single"
+	AlgorithmRooms  = "rooms"
+	AlgorithmBSP    = "bsp"
+
+	DefaultAlgorithm = AlgorithmRooms
+)
+
+// minimum dimensions a map needs for multi room algorithms
+const (
+	minMapWidth  = 16
+	minMapHeight = 8
+)
+
+type algorithm func(r *room.Room)
+
+var algorithms = map[string]algorithm{
+	AlgorithmSingle: func(r *room.Room) {},
+	AlgorithmRooms:  roomsAndCorridors,
+	AlgorithmBSP:    binarySpacePartition,
+}
+
+// Algorithms returns the names of all known generator algorithms.
+func Algorithms() []string {
+	names := make([]string, 0, len(algorithms))
+	for name := range algorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Generate builds a room of the given size using the named algorithm. Every
+// floor tile of the generated map is reachable from every other one, and the
+// same rng state always produces the same map.
+func Generate(name string, width, height, level int, rng *rand.Rand) (*room.Room, error) {
+	generate, ok := algorithms[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown generator algorithm %q", name)
+	}
+
+	if err := CheckSize(name, width, height); err != nil {
+		return nil, err
+	}
+
+	r := room.NewRoom(width, height, level, rng)
+	generate(r)
+
+	return r, nil
+}
+
+// CheckSize reports whether a map of the given size has room for the player
+// and the exit with the named algorithm.
+func CheckSize(name string, width, height int) error {
+	if name == AlgorithmSingle {
+		// the walls take up the outer ring, leave two floor tiles inside
+		if width < 3 || height < 3 || (width-2)*(height-2) < 2 {
+			return fmt.Errorf("map of %d x %d is too small to place the player and the exit", width, height)
+		}
+		return nil
+	}
+
+	if width < minMapWidth || height < minMapHeight {
+		return fmt.Errorf("%s maps must be at least %d x %d", name, minMapWidth, minMapHeight)
+	}
+	return nil
+}
+
+type rect struct {
+	x, y, w, h int
+}
+
+func (a rect) center() (int, int) {
+	return a.x + a.w/2, a.y + a.h/2
+}
+
+// overlaps reports whether two rects touch, keeping a wall between them.
+func (a rect) overlaps(b rect) bool {
+	return a.x <= b.x+b.w && b.x <= a.x+a.w && a.y <= b.y+b.h && b.y <= a.y+a.h
+}
+
+func fill(r *room.Room, id entity.ID) {
+	for x := 0; x < r.Width; x++ {
+		for y := 0; y < r.Height; y++ {
+			r.SetTile(x, y, id)
+		}
+	}
+}
+
+func carveRect(r *room.Room, a rect) {
+	for x := a.x; x < a.x+a.w; x++ {
+		for y := a.y; y < a.y+a.h; y++ {
+			r.SetTile(x, y, entity.ObjEmpty)
+		}
+	}
+}
+
+// carveCorridor digs an L shaped corridor between two points.
+func carveCorridor(r *room.Room, x1, y1, x2, y2 int, horizontalFirst bool) {
+	if horizontalFirst {
+		carveLine(r, x1, y1, x2, y1)
+		carveLine(r, x2, y1, x2, y2)
+	} else {
+		carveLine(r, x1, y1, x1, y2)
+		carveLine(r, x1, y2, x2, y2)
+	}
+}
+
+func carveLine(r *room.Room, x1, y1, x2, y2 int) {
+	for x := min(x1, x2); x <= max(x1, x2); x++ {
+		for y := min(y1, y2); y <= max(y1, y2); y++ {
+			r.SetTile(x, y, entity.ObjEmpty)
+		}
+	}
+}
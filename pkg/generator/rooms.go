@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"bitcrawler/pkg/entity"
+	"bitcrawler/pkg/room"
+)
+
+const (
+	roomAttempts = 60
+	roomMinSize  = 3
+)
+
+// roomsAndCorridors scatters non overlapping rooms across the map and joins
+// each one to the previous with a corridor.
+func roomsAndCorridors(r *room.Room) {
+	fill(r, entity.ObjWall)
+
+	maxWidth := max(roomMinSize+1, r.Width/4)
+	maxHeight := max(roomMinSize+1, r.Height/3)
+	maxRooms := max(2, (r.Width*r.Height)/80)
+
+	var rooms []rect
+	for i := 0; i < roomAttempts && len(rooms) < maxRooms; i++ {
+		w := roomMinSize + r.Rand.Intn(maxWidth-roomMinSize+1)
+		h := roomMinSize + r.Rand.Intn(maxHeight-roomMinSize+1)
+		if w > r.Width-2 || h > r.Height-2 {
+			continue
+		}
+
+		candidate := rect{
+			x: 1 + r.Rand.Intn(r.Width-w-1),
+			y: 1 + r.Rand.Intn(r.Height-h-1),
+			w: w,
+			h: h,
+		}
+
+		var overlapping bool
+		for _, other := range rooms {
+			if candidate.overlaps(other) {
+				overlapping = true
+				break
+			}
+		}
+		if overlapping {
+			continue
+		}
+
+		carveRect(r, candidate)
+		if len(rooms) > 0 {
+			x1, y1 := rooms[len(rooms)-1].center()
+			x2, y2 := candidate.center()
+			carveCorridor(r, x1, y1, x2, y2, r.Rand.Intn(2) == 0)
+		}
+		rooms = append(rooms, candidate)
+	}
+}
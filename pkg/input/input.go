@@ -0,0 +1,89 @@
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Source provides player commands one at a time. Sources return io.EOF
+// once they have no more commands.
+type Source interface {
+	ReadCommand() (string, error)
+}
+
+// Answerer is a Source that reads answers to prompts differently from
+// commands, such as Keys, whose bindings would turn y or n into moves.
+type Answerer interface {
+	ReadAnswer() (string, error)
+}
+
+// ReadAnswer reads the answer to a prompt from a source, bypassing any key
+// bindings it has.
+func ReadAnswer(s Source) (string, error) {
+	if a, ok := s.(Answerer); ok {
+		return a.ReadAnswer()
+	}
+	return s.ReadCommand()
+}
+
+// Terminal reads one command per line from an interactive reader. It keeps
+// a single scanner so lines buffered ahead of time are not lost.
+type Terminal struct {
+	scanner *bufio.Scanner
+}
+
+func NewTerminal(r io.Reader) *Terminal {
+	return &Terminal{scanner: bufio.NewScanner(r)}
+}
+
+func (t *Terminal) ReadCommand() (string, error) {
+	if !t.scanner.Scan() {
+		if err := t.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return t.scanner.Text(), nil
+}
+
+// Scripted replays a fixed list of commands.
+type Scripted struct {
+	commands []string
+	next     int
+}
+
+func NewScripted(commands ...string) *Scripted {
+	return &Scripted{commands: commands}
+}
+
+func (s *Scripted) ReadCommand() (string, error) {
+	if s.next >= len(s.commands) {
+		return "", io.EOF
+	}
+	command := s.commands[s.next]
+	s.next++
+	return command, nil
+}
+
+// NewFile loads a script of commands, one per line. Blank lines and lines
+// starting with # are skipped.
+func NewFile(path string) (*Scripted, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read command file: %w", err)
+	}
+
+	var commands []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		commands = append(commands, line)
+	}
+
+	return NewScripted(commands...), nil
+}
@@ -0,0 +1,213 @@
+package input
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+)
+
+const (
+	ModeText = "text"
+	ModeKeys = "keys"
+
+	// KeyCommandLine opens a line for typing a full text command
+	KeyCommandLine = ":"
+)
+
+var Modes = []string{ModeText, ModeKeys}
+
+const (
+	keyEscape    = 0x1b
+	keyEnter     = '\r'
+	keyNewline   = '\n'
+	keyBackspace = 0x7f
+	keyDelete    = 0x08
+)
+
+// escapeKeys names the final byte of the escape sequences sent by arrow
+// keys and the numpad with num lock off
+var escapeKeys = map[byte]string{
+	'A': "up",
+	'B': "down",
+	'C': "right",
+	'D': "left",
+	'E': "center",
+	'G': "center",
+	'H': "home",
+	'F': "end",
+}
+
+// escapeTildeKeys are sequences of the form ESC [ n ~
+var escapeTildeKeys = map[string]string{
+	"1": "home",
+	"4": "end",
+	"5": "pgup",
+	"6": "pgdn",
+	"7": "home",
+	"8": "end",
+}
+
+// DefaultBindings maps vi-keys, arrow keys and the numpad to commands.
+func DefaultBindings() map[string]string {
+	return map[string]string{
+		// vi-keys
+		"h": "move west",
+		"j": "move south",
+		"k": "move north",
+		"l": "move east",
+		"y": "move northwest",
+		"u": "move northeast",
+		"b": "move southwest",
+		"n": "move southeast",
+
+		// arrow keys and the numpad with num lock off
+		"up":     "move north",
+		"down":   "move south",
+		"left":   "move west",
+		"right":  "move east",
+		"home":   "move northwest",
+		"pgup":   "move northeast",
+		"end":    "move southwest",
+		"pgdn":   "move southeast",
+		"center": "wait",
+
+		// numpad with num lock on
+		"8": "move north",
+		"2": "move south",
+		"4": "move west",
+		"6": "move east",
+		"7": "move northwest",
+		"9": "move northeast",
+		"1": "move southwest",
+		"3": "move southeast",
+		"5": "wait",
+
+		// actions
+		".": "wait",
+		"i": "inventory",
+		",": "pick all",
+		"g": "pick all",
+		"R": "rest",
+		"S": "save quick",
+		"L": "load quick",
+		"Q": "quit",
+		"[": "journal up",
+		"]": "journal down",
+	}
+}
+
+// Keys turns single keystrokes into commands using a set of bindings. The
+// terminal has to be in key mode, see terminal.EnableKeyMode.
+type Keys struct {
+	reader   *bufio.Reader
+	echo     io.Writer
+	bindings map[string]string
+}
+
+// NewKeys reads keystrokes from r. Overrides replace or add to the default
+// bindings. Typed text commands are echoed to echo.
+func NewKeys(r io.Reader, echo io.Writer, overrides map[string]string) *Keys {
+	bindings := DefaultBindings()
+	maps.Copy(bindings, overrides)
+	return &Keys{reader: bufio.NewReader(r), echo: echo, bindings: bindings}
+}
+
+func (k *Keys) ReadCommand() (string, error) {
+	for {
+		key, err := k.readKey()
+		if err != nil {
+			return "", err
+		}
+
+		if key == KeyCommandLine {
+			return k.readLine()
+		}
+
+		if command, ok := k.bindings[key]; ok && command != "" {
+			return command, nil
+		}
+	}
+}
+
+// ReadAnswer returns the next key pressed as typed, ignoring the bindings,
+// or a whole line when the command line is opened.
+func (k *Keys) ReadAnswer() (string, error) {
+	key, err := k.readKey()
+	if err != nil {
+		return "", err
+	}
+	if key == KeyCommandLine {
+		return k.readLine()
+	}
+	return key, nil
+}
+
+// readKey returns the name of the next key pressed.
+func (k *Keys) readKey() (string, error) {
+	b, err := k.reader.ReadByte()
+	if err != nil {
+		return "", err
+	}
+
+	if b != keyEscape {
+		return string(b), nil
+	}
+
+	// arrow keys and friends arrive as ESC [ x or ESC O x in a single
+	// read, so an ESC with nothing buffered behind it is a lone key press
+	if k.reader.Buffered() == 0 {
+		return "escape", nil
+	}
+	if next, _ := k.reader.Peek(1); next[0] != '[' && next[0] != 'O' {
+		return "escape", nil
+	}
+	k.reader.ReadByte()
+
+	var sequence strings.Builder
+	for {
+		b, err := k.reader.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		if b >= '0' && b <= '9' || b == ';' {
+			sequence.WriteByte(b)
+			continue
+		}
+		if b == '~' {
+			return escapeTildeKeys[sequence.String()], nil
+		}
+		return escapeKeys[b], nil
+	}
+}
+
+// readLine reads a full text command, echoing as the player types.
+func (k *Keys) readLine() (string, error) {
+	fmt.Fprint(k.echo, KeyCommandLine)
+
+	var line []byte
+	for {
+		b, err := k.reader.ReadByte()
+		if err != nil {
+			return "", err
+		}
+
+		switch b {
+		case keyEnter, keyNewline:
+			fmt.Fprintln(k.echo)
+			return string(line), nil
+		case keyEscape:
+			fmt.Fprintln(k.echo)
+			return "", nil
+		case keyBackspace, keyDelete:
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+				fmt.Fprint(k.echo, "\b \b")
+			}
+		default:
+			line = append(line, b)
+			fmt.Fprint(k.echo, string(b))
+		}
+	}
+}
@@ -3,6 +3,7 @@ package logging
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -15,13 +16,37 @@ const (
 	LogLevelDebug
 )
 
+const (
+	DefaultLogFile = "game.log"
+	StderrLogFile  = "stderr"
+)
+
 type Logger struct {
 	Level   LogLevel
 	LogFile *os.File
 }
 
-func openLogFile() (*os.File, error) {
-	file, err := os.OpenFile("game.log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+func ParseLogLevel(level string) (LogLevel, error) {
+	switch strings.ToLower(level) {
+	case "error":
+		return LogLevelError, nil
+	case "warning", "warn":
+		return LogLevelWarning, nil
+	case "info":
+		return LogLevelInfo, nil
+	case "debug":
+		return LogLevelDebug, nil
+	default:
+		return LogLevelError, fmt.Errorf("unknown log level %q", level)
+	}
+}
+
+func openLogFile(path string) (*os.File, error) {
+	if path == StderrLogFile {
+		return os.Stderr, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return nil, err
 	}
@@ -46,8 +71,8 @@ func (l *Logger) LogMessage(level LogLevel, message string) {
 	}
 }
 
-func NewLogger(level LogLevel) (*Logger, error) {
-	file, err := openLogFile()
+func NewLogger(level LogLevel, path string) (*Logger, error) {
+	file, err := openLogFile(path)
 	if err != nil {
 		return nil, err
 	}
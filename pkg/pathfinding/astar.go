@@ -0,0 +1,122 @@
+package pathfinding
+
+import (
+	"container/heap"
+)
+
+// Impassable is returned by a CostFunc for tiles that cannot be entered.
+const Impassable = -1
+
+const (
+	DefaultStraightCost = 10
+	DefaultDiagonalCost = 14
+	DefaultMaxNodes     = 2000
+)
+
+type Point struct {
+	X, Y int
+}
+
+// CostFunc returns the extra cost of entering a tile on top of the step
+// cost, or Impassable if the tile cannot be entered.
+type CostFunc func(x, y int) int
+
+// Options tune the search. Zero values fall back to the defaults.
+type Options struct {
+	StraightCost int
+	DiagonalCost int
+	// MaxNodes caps how many tiles are expanded before giving up
+	MaxNodes int
+}
+
+func (o Options) withDefaults() Options {
+	if o.StraightCost <= 0 {
+		o.StraightCost = DefaultStraightCost
+	}
+	if o.DiagonalCost <= 0 {
+		o.DiagonalCost = DefaultDiagonalCost
+	}
+	if o.MaxNodes <= 0 {
+		o.MaxNodes = DefaultMaxNodes
+	}
+	return o
+}
+
+var neighbors = []Point{
+	{0, 1}, {1, 0}, {0, -1}, {-1, 0},
+	{1, 1}, {1, -1}, {-1, 1}, {-1, -1},
+}
+
+// FindPath searches for the cheapest 8-way path from start to goal with A*.
+// The returned path excludes the start and ends at the goal. When the goal
+// cannot be reached within the search budget, the path leads to the
+// explored tile closest to the goal and the second result is false.
+func FindPath(start, goal Point, cost CostFunc, opts Options) ([]Point, bool) {
+	opts = opts.withDefaults()
+
+	if start == goal {
+		return nil, true
+	}
+
+	open := &nodeHeap{}
+	nodes := map[Point]*node{}
+
+	first := &node{point: start, h: heuristic(start, goal, opts)}
+	nodes[start] = first
+	heap.Push(open, first)
+	closest := first
+
+	for expanded := 0; open.Len() > 0 && expanded < opts.MaxNodes; expanded++ {
+		current := heap.Pop(open).(*node)
+		current.closed = true
+
+		if current.point == goal {
+			return current.path(), true
+		}
+
+		if current.h < closest.h {
+			closest = current
+		}
+
+		for _, d := range neighbors {
+			next := Point{current.point.X + d.X, current.point.Y + d.Y}
+
+			extra := cost(next.X, next.Y)
+			if extra == Impassable && next != goal {
+				continue
+			}
+
+			step := opts.StraightCost
+			if d.X != 0 && d.Y != 0 {
+				step = opts.DiagonalCost
+			}
+			g := current.g + step + max(0, extra)
+
+			n, seen := nodes[next]
+			if seen && (n.closed || g >= n.g) {
+				continue
+			}
+			if !seen {
+				n = &node{point: next, h: heuristic(next, goal, opts)}
+				nodes[next] = n
+			}
+
+			n.g = g
+			n.parent = current
+			if seen {
+				heap.Fix(open, n.index)
+			} else {
+				heap.Push(open, n)
+			}
+		}
+	}
+
+	return closest.path(), false
+}
+
+// heuristic is the octile distance, exact on an empty grid.
+func heuristic(a, b Point, opts Options) int {
+	dx := max(a.X-b.X, b.X-a.X)
+	dy := max(a.Y-b.Y, b.Y-a.Y)
+	return opts.StraightCost*(max(dx, dy)-min(dx, dy)) + opts.DiagonalCost*min(dx, dy)
+}
@@ -0,0 +1,58 @@
+package pathfinding
+
+type node struct {
+	point  Point
+	g, h   int
+	parent *node
+	closed bool
+	index  int
+}
+
+func (n *node) f() int {
+	return n.g + n.h
+}
+
+// path walks back to the start, leaving the start itself out.
+func (n *node) path() []Point {
+	var path []Point
+	for current := n; current.parent != nil; current = current.parent {
+		path = append(path, current.point)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// nodeHeap is a min-heap of open nodes ordered by estimated total cost.
+type nodeHeap []*node
+
+func (h nodeHeap) Len() int { return len(h) }
+
+func (h nodeHeap) Less(i, j int) bool {
+	if h[i].f() == h[j].f() {
+		return h[i].h < h[j].h
+	}
+	return h[i].f() < h[j].f()
+}
+
+func (h nodeHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *nodeHeap) Push(x any) {
+	n := x.(*node)
+	n.index = len(*h)
+	*h = append(*h, n)
+}
+
+func (h *nodeHeap) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	old[len(old)-1] = nil
+	n.index = -1
+	*h = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,134 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// SidePanelWidth is the width in characters of the panel beside the map
+	SidePanelWidth = 32
+	// MessagePaneHeight is how many lines of message history are shown
+	MessagePaneHeight = 6
+	// ChromeRows is how many rows the layout uses besides the map
+	ChromeRows = MessagePaneHeight + 4
+
+	panelSeparator = " | "
+)
+
+type Status struct {
+	Name       string
+	HP         int
+	MaxHP      int
+	Mana       int
+	MaxMana    int
+	Stamina    int
+	MaxStamina int
+	Level      int
+	Turn       int
+	Abilities  []string
+	Effects    []string
+}
+
+// EnemyInfo describes an enemy in view for the side panel.
+type EnemyInfo struct {
+	Glyph  rune
+	Name   string
+	Health string
+}
+
+// compose lays the frame out as text: status bar, map with the side panel
+// to its right, message pane and prompt.
+func compose(frame Frame, cell func(glyph rune, remembered bool) string) []string {
+	var lines []string
+	lines = append(lines, statusBar(frame.Status))
+
+	panel := sidePanel(frame.Enemies, len(frame.Map))
+	for i, row := range frame.Map {
+		var line strings.Builder
+		for _, c := range row {
+			line.WriteString(cell(c.Glyph, c.Remembered))
+		}
+		if i < len(panel) {
+			line.WriteString(panelSeparator + panel[i])
+		}
+		lines = append(lines, line.String())
+	}
+
+	header := "-- Messages "
+	if frame.MessageScroll > 0 {
+		header += fmt.Sprintf("(%d newer) ", frame.MessageScroll)
+	}
+	lines = append(lines, header+strings.Repeat("-", max(0, 40-len(header))))
+
+	messages := frame.Messages
+	if len(messages) > MessagePaneHeight {
+		messages = messages[len(messages)-MessagePaneHeight:]
+	}
+	for i := 0; i < MessagePaneHeight; i++ {
+		if i < len(messages) {
+			lines = append(lines, messages[i])
+		} else {
+			lines = append(lines, "")
+		}
+	}
+
+	if frame.Prompt != "" {
+		lines = append(lines, frame.Prompt)
+	}
+
+	return lines
+}
+
+func statusBar(status Status) string {
+	if status.Name == "" {
+		return ""
+	}
+
+	parts := []string{
+		status.Name,
+		fmt.Sprintf("HP %d/%d", status.HP, status.MaxHP),
+	}
+	if status.MaxMana > 0 {
+		parts = append(parts, fmt.Sprintf("MP %d/%d", status.Mana, status.MaxMana))
+	}
+	if status.MaxStamina > 0 {
+		parts = append(parts, fmt.Sprintf("SP %d/%d", status.Stamina, status.MaxStamina))
+	}
+	parts = append(parts,
+		fmt.Sprintf("Level %d", status.Level),
+		fmt.Sprintf("Turn %d", status.Turn),
+	)
+	if len(status.Abilities) > 0 {
+		parts = append(parts, strings.Join(status.Abilities, ", "))
+	}
+	if len(status.Effects) > 0 {
+		parts = append(parts, strings.Join(status.Effects, ", "))
+	}
+	return strings.Join(parts, " | ")
+}
+
+func sidePanel(enemies []EnemyInfo, height int) []string {
+	lines := []string{"Enemies in view"}
+	if len(enemies) == 0 {
+		lines = append(lines, "  none")
+	}
+
+	for i, enemy := range enemies {
+		if len(lines) == height-1 && i < len(enemies)-1 {
+			lines = append(lines, fmt.Sprintf("  ... %d more", len(enemies)-i))
+			break
+		}
+		lines = append(lines, truncate(fmt.Sprintf("%c %s - %s", enemy.Glyph, enemy.Name, enemy.Health), SidePanelWidth))
+	}
+
+	return lines
+}
+
+func truncate(text string, width int) string {
+	if utf8.RuneCountInString(text) <= width {
+		return text
+	}
+	return string([]rune(text)[:width-1]) + "~"
+}
@@ -0,0 +1,109 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"bitcrawler/pkg/room"
+)
+
+const (
+	RendererANSI     = "ansi"
+	RendererPlain    = "plain"
+	RendererHeadless = "headless"
+)
+
+var Renderers = []string{RendererANSI, RendererPlain, RendererHeadless}
+
+// Frame is everything shown to the player for one turn.
+type Frame struct {
+	Map     [][]room.Cell
+	Status  Status
+	Enemies []EnemyInfo
+	// Messages is the message history up to the scroll position, newest last
+	Messages      []string
+	MessageScroll int
+	Prompt        string
+}
+
+// Renderer shows frames and standalone text to the player.
+type Renderer interface {
+	Render(frame Frame) error
+	Show(text string) error
+}
+
+// New returns the renderer with the given name writing to w.
+func New(name string, w io.Writer) (Renderer, error) {
+	switch name {
+	case RendererANSI:
+		return &ANSI{Out: w}, nil
+	case RendererPlain:
+		return &Plain{Out: w}, nil
+	case RendererHeadless:
+		return Headless{}, nil
+	default:
+		return nil, fmt.Errorf("unknown renderer %q", name)
+	}
+}
+
+const (
+	clearScreen = "\033[H\033[2J" // Clear the screen and move the cursor to the top-left
+	dimStart    = "\033[2m"
+	dimEnd      = "\033[0m"
+)
+
+// ANSI draws to a terminal, clearing the screen every frame and dimming
+// remembered tiles.
+type ANSI struct {
+	Out io.Writer
+}
+
+func (a *ANSI) Render(frame Frame) error {
+	lines := compose(frame, func(glyph rune, remembered bool) string {
+		if remembered {
+			return dimStart + string(glyph) + " " + dimEnd
+		}
+		return string(glyph) + " "
+	})
+
+	_, err := io.WriteString(a.Out, clearScreen+strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+func (a *ANSI) Show(text string) error {
+	_, err := fmt.Fprintln(a.Out, text)
+	return err
+}
+
+// Plain writes frames as plain text without escape codes, which keeps the
+// output stable for tests and logs.
+type Plain struct {
+	Out io.Writer
+}
+
+func (p *Plain) Render(frame Frame) error {
+	lines := compose(frame, func(glyph rune, _ bool) string {
+		return string(glyph) + " "
+	})
+
+	var builder strings.Builder
+	for _, line := range lines {
+		builder.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+
+	_, err := io.WriteString(p.Out, builder.String())
+	return err
+}
+
+func (p *Plain) Show(text string) error {
+	_, err := fmt.Fprintln(p.Out, text)
+	return err
+}
+
+// Headless drops all output, for embedding and benchmarks.
+type Headless struct{}
+
+func (Headless) Render(Frame) error { return nil }
+
+func (Headless) Show(string) error { return nil }
@@ -0,0 +1,97 @@
+package room
+
+import (
+	"math"
+
+	"bitcrawler/pkg/entity"
+)
+
+// coneSpread is the cosine of half the cone's angle, a quarter circle
+const coneSpread = math.Sqrt2 / 2
+
+// AbilityArea returns the tiles an ability covers when cast from x, y
+// towards tx, ty. Bolts stop at the first living character or wall in
+// their way, cones and bursts reach every tile in sight of the caster.
+func (r *Room) AbilityArea(shape entity.AbilityShape, x, y, tx, ty, reach int) []*Coordinate {
+	switch shape {
+	case entity.ShapeSelf:
+		return []*Coordinate{r.Grid[x][y]}
+	case entity.ShapeBolt:
+		return r.bolt(x, y, tx, ty, reach)
+	case entity.ShapeCone:
+		return r.spread(x, y, reach, func(ox, oy int) bool {
+			return inCone(ox, oy, tx-x, ty-y)
+		})
+	case entity.ShapeBurst:
+		return r.spread(x, y, reach, func(int, int) bool { return true })
+	}
+	return nil
+}
+
+func (r *Room) bolt(x, y, tx, ty, reach int) []*Coordinate {
+	dx, dy := tx-x, ty-y
+	steps := max(Abs(dx), Abs(dy))
+	if steps == 0 || reach <= 0 {
+		return nil
+	}
+
+	// aim past the target so the bolt flies its full reach
+	scale := (reach + steps - 1) / steps
+	dx, dy = dx*scale, dy*scale
+	adx, ady := Abs(dx), -Abs(dy)
+	sx, sy := sign(dx), sign(dy)
+	err := adx + ady
+
+	var tiles []*Coordinate
+	cx, cy := x, y
+	for len(tiles) < reach {
+		e2 := 2 * err
+		if e2 >= ady {
+			err += ady
+			cx += sx
+		}
+		if e2 <= adx {
+			err += adx
+			cy += sy
+		}
+
+		if r.blocksSight(cx, cy) {
+			break
+		}
+		tiles = append(tiles, r.Grid[cx][cy])
+		if isAlive(r.Grid[cx][cy].Entity) {
+			break
+		}
+	}
+	return tiles
+}
+
+func (r *Room) spread(x, y, reach int, include func(ox, oy int) bool) []*Coordinate {
+	var tiles []*Coordinate
+	for ox := -reach; ox <= reach; ox++ {
+		for oy := -reach; oy <= reach; oy++ {
+			tx, ty := x+ox, y+oy
+			if (ox == 0 && oy == 0) || !r.InBounds(tx, ty) || r.blocksSight(tx, ty) {
+				continue
+			}
+			if include(ox, oy) && r.HasLineOfSight(x, y, tx, ty) {
+				tiles = append(tiles, r.Grid[tx][ty])
+			}
+		}
+	}
+	return tiles
+}
+
+// inCone reports whether an offset lies within a quarter circle around
+// the direction dx, dy.
+func inCone(ox, oy, dx, dy int) bool {
+	length := math.Hypot(float64(ox), float64(oy)) * math.Hypot(float64(dx), float64(dy))
+	if length == 0 {
+		return false
+	}
+	return float64(ox*dx+oy*dy)/length >= coneSpread-1e-9
+}
+
+func isAlive(c *entity.Character) bool {
+	return c != nil && (c.ID == entity.ObjPlayer || c.ID == entity.ObjEnemy) && c.HP > 0
+}
@@ -0,0 +1,32 @@
+package room
+
+// SetViewport sets how many tiles of the map are drawn at once. A zero
+// dimension draws the whole map along that axis.
+func (r *Room) SetViewport(width, height int) {
+	r.ViewWidth = max(0, width)
+	r.ViewHeight = max(0, height)
+}
+
+// CenterCamera moves the viewport so the given coordinates sit in its
+// middle, without scrolling past the edges of the map.
+func (r *Room) CenterCamera(x, y int) {
+	viewWidth, viewHeight := r.viewSize()
+	r.CameraX = clamp(x-viewWidth/2, 0, r.Width-viewWidth)
+	r.CameraY = clamp(y-viewHeight/2, 0, r.Height-viewHeight)
+}
+
+// viewSize returns the viewport dimensions, limited to the map size.
+func (r *Room) viewSize() (int, int) {
+	width, height := r.Width, r.Height
+	if r.ViewWidth > 0 {
+		width = min(width, r.ViewWidth)
+	}
+	if r.ViewHeight > 0 {
+		height = min(height, r.ViewHeight)
+	}
+	return width, height
+}
+
+func clamp(value, low, high int) int {
+	return max(low, min(value, high))
+}
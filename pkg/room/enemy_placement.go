@@ -10,6 +10,9 @@ func (r *Room) PlaceGoblinPack(goblins int, goblinLeader bool) []*entity.Charact
 	//logger.LogMessage(logging.LogLevelDebug,
 	//	fmt.Sprintf("Empty area found at coordinates: (%d, %d)", emptyX, emptyY))
 
+	r.packs++
+	packID := r.packs
+
 	var enemies []*entity.Character
 	var counter int
 	for _, coord := range emptyArea {
@@ -19,6 +22,8 @@ func (r *Room) PlaceGoblinPack(goblins int, goblinLeader bool) []*entity.Charact
 					goblinLeaderEnemy := entity.NewEnemy(entity.GoblinLeaderTemplate)
 					goblinLeaderEnemy.X = coord.X
 					goblinLeaderEnemy.Y = coord.Y
+					goblinLeaderEnemy.PackID = packID
+					goblinLeaderEnemy.IsLeader = true
 
 					r.AddEntity(&Coordinate{X: coord.X, Y: coord.Y, Entity: goblinLeaderEnemy})
 					enemies = append(enemies, goblinLeaderEnemy)
@@ -36,6 +41,7 @@ func (r *Room) PlaceGoblinPack(goblins int, goblinLeader bool) []*entity.Charact
 			e := entity.NewEnemy(entity.GoblinEnemyTemplate)
 			e.X = coord.X
 			e.Y = coord.Y
+			e.PackID = packID
 
 			r.AddEntity(&Coordinate{X: coord.X, Y: coord.Y, Entity: e})
 			enemies = append(enemies, e)
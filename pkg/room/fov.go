@@ -0,0 +1,159 @@
+package room
+
+import (
+	"bitcrawler/pkg/entity"
+)
+
+// octant transforms used by the shadowcasting below, one column per octant
+var octants = [4][8]int{
+	{1, 0, 0, -1, -1, 0, 0, 1},
+	{0, 1, -1, 0, 0, -1, 1, 0},
+	{0, 1, 1, 0, 0, -1, -1, 0},
+	{1, 0, 0, 1, -1, 0, 0, -1},
+}
+
+func newBoolGrid(width, height int) [][]bool {
+	grid := make([][]bool, width)
+	for i := range grid {
+		grid[i] = make([]bool, height)
+	}
+	return grid
+}
+
+func (r *Room) InBounds(x, y int) bool {
+	return x >= 0 && x < r.Width && y >= 0 && y < r.Height
+}
+
+func (r *Room) blocksSight(x, y int) bool {
+	return !r.InBounds(x, y) || r.Grid[x][y].Entity.ID == entity.ObjWall
+}
+
+// ComputeFOV marks every tile within the radius that has a clear line of
+// sight from the given coordinates as visible and remembers it as explored.
+func (r *Room) ComputeFOV(x, y, radius int) {
+	r.Visible = newBoolGrid(r.Width, r.Height)
+	if r.Explored == nil {
+		r.Explored = newBoolGrid(r.Width, r.Height)
+	}
+
+	if !r.InBounds(x, y) {
+		return
+	}
+
+	r.reveal(x, y)
+	for oct := 0; oct < 8; oct++ {
+		r.castLight(x, y, 1, 1.0, 0.0, radius,
+			octants[0][oct], octants[1][oct], octants[2][oct], octants[3][oct])
+	}
+}
+
+func (r *Room) reveal(x, y int) {
+	r.Visible[x][y] = true
+	r.Explored[x][y] = true
+}
+
+// castLight is a recursive shadowcaster that scans one octant row by row,
+// narrowing the visible slope range whenever it passes a wall.
+func (r *Room) castLight(cx, cy, row int, start, end float64, radius, xx, xy, yx, yy int) {
+	if start < end {
+		return
+	}
+
+	radiusSquared := radius * radius
+	for j := row; j <= radius; j++ {
+		dx, dy := -j-1, -j
+		blocked := false
+		newStart := start
+
+		for dx <= 0 {
+			dx++
+			x := cx + dx*xx + dy*xy
+			y := cy + dx*yx + dy*yy
+			leftSlope := (float64(dx) - 0.5) / (float64(dy) + 0.5)
+			rightSlope := (float64(dx) + 0.5) / (float64(dy) - 0.5)
+
+			if start < rightSlope {
+				continue
+			} else if end > leftSlope {
+				break
+			}
+
+			if dx*dx+dy*dy < radiusSquared && r.InBounds(x, y) {
+				r.reveal(x, y)
+			}
+
+			if blocked {
+				if r.blocksSight(x, y) {
+					newStart = rightSlope
+					continue
+				}
+				blocked = false
+				start = newStart
+			} else if r.blocksSight(x, y) && j < radius {
+				blocked = true
+				r.castLight(cx, cy, j+1, start, leftSlope, radius, xx, xy, yx, yy)
+				newStart = rightSlope
+			}
+		}
+
+		if blocked {
+			break
+		}
+	}
+}
+
+// IsVisible reports whether the tile is in view. Rooms without a computed
+// field of view show everything.
+func (r *Room) IsVisible(x, y int) bool {
+	if r.Visible == nil {
+		return true
+	}
+	return r.InBounds(x, y) && r.Visible[x][y]
+}
+
+// IsExplored reports whether the tile has ever been seen.
+func (r *Room) IsExplored(x, y int) bool {
+	if r.Explored == nil {
+		return true
+	}
+	return r.InBounds(x, y) && r.Explored[x][y]
+}
+
+// HasLineOfSight reports whether nothing blocks sight on the straight line
+// between two tiles. The end points themselves are not checked.
+func (r *Room) HasLineOfSight(x1, y1, x2, y2 int) bool {
+	dx, dy := Abs(x2-x1), -Abs(y2-y1)
+	sx, sy := sign(x2-x1), sign(y2-y1)
+	err := dx + dy
+
+	x, y := x1, y1
+	for {
+		if x == x2 && y == y2 {
+			return true
+		}
+		if (x != x1 || y != y1) && r.blocksSight(x, y) {
+			return false
+		}
+
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+	}
+}
+
+func sign(x int) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
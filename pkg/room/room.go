@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"strings"
 
+	"bitcrawler/pkg/combat"
 	"bitcrawler/pkg/entity"
 )
 
@@ -13,15 +14,26 @@ type Room struct {
 	Level            int
 	Width, Height    int
 	CameraX, CameraY int
+	ViewWidth        int
+	ViewHeight       int
+	Rand             *rand.Rand
 	Grid             [][]*Coordinate
+	Visible          [][]bool
+	Explored         [][]bool
 	DungeonView      *DungeonView
 	LogView          strings.Builder
+
+	// OnAttack is told about every resolved attack
+	OnAttack func(attacker, defender *entity.Character, result combat.Result)
+
+	packs int
 }
 
 type DungeonView string
 
 type Coordinate struct {
 	Entity *entity.Character
+	Items  []*entity.Item
 	X      int
 	Y      int
 }
@@ -37,7 +49,7 @@ var (
 	}
 )
 
-func NewRoom(width, height, level int) *Room {
+func NewRoom(width, height, level int, rng *rand.Rand) *Room {
 	grid := make([][]*Coordinate, width)
 	for i := range grid {
 		grid[i] = make([]*Coordinate, height)
@@ -50,41 +62,102 @@ func NewRoom(width, height, level int) *Room {
 			grid[i][j] = &Coordinate{X: i, Y: j, Entity: &entity.Character{ID: defaultID}}
 		}
 	}
-	return &Room{Width: width, Height: height, Grid: grid, Level: level}
+	return &Room{Width: width, Height: height, Grid: grid, Level: level, Rand: rng}
 }
 
 func (r *Room) AddEntity(c *Coordinate) {
 	if c.X >= 0 && c.X < r.Width && c.Y >= 0 && c.Y < r.Height {
+		// keep anything lying on the floor beneath the new entity
+		c.Items = append(r.Grid[c.X][c.Y].Items, c.Items...)
 		r.Grid[c.X][c.Y] = c
 	}
 }
 
-func (r *Room) DrawRoom() {
-	var builder strings.Builder
-	for y := r.Height - 1; y >= 0; y-- { // Start from the top row
-		for x := 0; x < r.Width; x++ {
-			switch r.Grid[x][y].Entity.ID {
-			case entity.ObjEmpty:
-				builder.WriteString(". ")
-			case entity.ObjPlayer, entity.ObjEnemy:
-				if r.Grid[x][y].Entity.ID == entity.ObjEnemy && r.Grid[x][y].Entity.HasDied {
-					builder.WriteString("x ")
-				} else {
-					builder.WriteString(string(r.Grid[x][y].Entity.Visual) + " ")
-				}
-			case entity.ObjWall:
-				builder.WriteString("# ")
+// SetTile replaces whatever is at the given coordinates with plain terrain.
+func (r *Room) SetTile(x, y int, id entity.ID) {
+	if x >= 0 && x < r.Width && y >= 0 && y < r.Height {
+		r.Grid[x][y].Entity = &entity.Character{ID: id}
+	}
+}
+
+func (r *Room) DropItem(x, y int, item *entity.Item) {
+	if x >= 0 && x < r.Width && y >= 0 && y < r.Height {
+		r.Grid[x][y].Items = append(r.Grid[x][y].Items, item)
+	}
+}
+
+func (r *Room) PickItem(x, y int, name string) (*entity.Item, error) {
+	if x < 0 || x >= r.Width || y < 0 || y >= r.Height {
+		return nil, errors.New("coordinates out of bounds")
+	}
+
+	items := r.Grid[x][y].Items
+	for i, item := range items {
+		if entity.MatchesItem(item, name) {
+			r.Grid[x][y].Items = append(items[:i], items[i+1:]...)
+			return item, nil
+		}
+	}
+
+	return nil, fmt.Errorf("there is no %s here", name)
+}
+
+// Cell is one tile of the map as the player sees it.
+type Cell struct {
+	Glyph rune
+	// Remembered tiles are out of view and show what the player saw last
+	Remembered bool
+}
+
+// View returns the tiles inside the viewport, top row first. Unexplored
+// tiles are blank.
+func (r *Room) View() [][]Cell {
+	viewWidth, viewHeight := r.viewSize()
+	rows := make([][]Cell, 0, viewHeight)
+	for y := r.CameraY + viewHeight - 1; y >= r.CameraY; y-- { // Start from the top row
+		row := make([]Cell, 0, viewWidth)
+		for x := r.CameraX; x < r.CameraX+viewWidth; x++ {
+			switch {
+			case r.IsVisible(x, y):
+				row = append(row, Cell{Glyph: r.glyph(x, y, true)})
+			case r.IsExplored(x, y):
+				row = append(row, Cell{Glyph: r.glyph(x, y, false), Remembered: true})
 			default:
-				builder.WriteString("? ") // Unknown entity
+				row = append(row, Cell{Glyph: ' '}) // Unexplored
 			}
 		}
-		builder.WriteString("\n") // Move to the next row
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// glyph returns the rune a tile is drawn with. Tiles out of view only show
+// what the player remembers, so characters on them are hidden.
+func (r *Room) glyph(x, y int, visible bool) rune {
+	tile := r.Grid[x][y]
+	switch tile.Entity.ID {
+	case entity.ObjEmpty:
+		return floorGlyph(tile)
+	case entity.ObjPlayer, entity.ObjEnemy, entity.ObjExit:
+		if !visible && tile.Entity.ID != entity.ObjExit {
+			return floorGlyph(tile)
+		}
+		if tile.Entity.ID == entity.ObjEnemy && tile.Entity.HasDied {
+			return 'x'
+		}
+		return tile.Entity.Visual
+	case entity.ObjWall:
+		return '#'
+	default:
+		return '?' // Unknown entity
 	}
+}
 
-	builder.WriteString(r.LogView.String())
-	r.LogView.Reset()
-	fmt.Print("\033[H\033[2J") // Clear the screen and move the cursor to the top-left
-	fmt.Printf("%s", builder.String())
+func floorGlyph(tile *Coordinate) rune {
+	if len(tile.Items) > 0 {
+		return tile.Items[len(tile.Items)-1].Visual
+	}
+	return '.'
 }
 
 func (r *Room) Move(character *entity.Character, x, y int) error {
@@ -153,7 +226,7 @@ func (r *Room) AddWallsWithDoorway(horizontal bool, position int) {
 		if position < 0 || position >= r.Height {
 			return // Invalid position
 		}
-		doorway := rand.Intn(r.Width) // Random doorway position
+		doorway := r.Rand.Intn(r.Width) // Random doorway position
 		for x := 0; x < r.Width; x++ {
 			if x == doorway {
 				continue // Leave a doorway
@@ -165,7 +238,7 @@ func (r *Room) AddWallsWithDoorway(horizontal bool, position int) {
 		if position < 0 || position >= r.Width {
 			return // Invalid position
 		}
-		doorway := rand.Intn(r.Height) // Random doorway position
+		doorway := r.Rand.Intn(r.Height) // Random doorway position
 		for y := 0; y < r.Height; y++ {
 			if y == doorway {
 				continue // Leave a doorway
@@ -175,45 +248,50 @@ func (r *Room) AddWallsWithDoorway(horizontal bool, position int) {
 	}
 }
 
-func (r *Room) AttackEntity(attacker, defender *entity.Character) {
+// AttackEntity resolves an attack and reports the outcome in the log.
+func (r *Room) AttackEntity(attacker, defender *entity.Character) combat.Result {
 	if defender.ID == entity.ObjEmpty {
 		r.LogView.WriteString("You attack into the air and almost hit yourself!\n")
-		return
+		return combat.Result{}
 	}
 
 	if defender.ID == entity.ObjWall {
 		r.LogView.WriteString("You attack and hit a wall!\n")
-		return
+		return combat.Result{}
 	}
 
-	if defender.HP > 0 {
-		r.LogView.WriteString(fmt.Sprintf("%s attacks %s!\n", attacker.Name, defender.Name))
-
-		// calculate abilities
-		var attackerAttackIncrease int
-		var defenderDefenseIncrease int
-
-		if len(attacker.Abilities) > 0 {
-			for _, ability := range attacker.Abilities {
-				attackerAttackIncrease += ability.Effect.Attack
-			}
-		}
+	if defender.HP <= 0 {
+		r.LogView.WriteString(fmt.Sprintf("%s is already defeated!\n", defender.Name))
+		return combat.Result{}
+	}
 
-		if len(defender.Abilities) > 0 {
-			for _, ability := range defender.Abilities {
-				defenderDefenseIncrease += ability.Effect.Defense
-			}
-		}
+	result := combat.Resolve(r.Rand, attacker, defender)
+	if r.OnAttack != nil {
+		r.OnAttack(attacker, defender, result)
+	}
+	switch {
+	case !result.Hit:
+		r.LogView.WriteString(fmt.Sprintf("%s attacks %s and misses.\n", attacker.Name, defender.Name))
+		return result
+	case result.Critical:
+		r.LogView.WriteString(fmt.Sprintf("%s lands a critical hit on %s for %d damage!", attacker.Name, defender.Name, result.Damage))
+	default:
+		r.LogView.WriteString(fmt.Sprintf("%s hits %s for %d damage.", attacker.Name, defender.Name, result.Damage))
+	}
+	if result.Resisted > 0 {
+		r.LogView.WriteString(fmt.Sprintf(" %s resists %d %s damage.", defender.Name, result.Resisted, result.Type))
+	}
+	r.LogView.WriteString("\n")
 
-		defender.HP -= (attacker.Attack + attackerAttackIncrease) - (defender.Defense - defenderDefenseIncrease)
-		if defender.HP <= 0 {
-			r.LogView.WriteString(fmt.Sprintf("%s is defeated!\n", defender.Name))
-		} else {
-			r.LogView.WriteString(fmt.Sprintf("%s has %d HP left.\n", defender.Name, defender.HP))
-		}
+	if result.Killed {
+		r.LogView.WriteString(fmt.Sprintf("%s is defeated!\n", defender.Name))
 	} else {
-		r.LogView.WriteString(fmt.Sprintf("%s is already defeated!\n", defender.Name))
+		r.LogView.WriteString(fmt.Sprintf("%s has %d HP left.\n", defender.Name, defender.HP))
+	}
+	for _, status := range result.Inflicted {
+		r.LogView.WriteString(fmt.Sprintf("%s is %s!\n", defender.Name, status.Adjective()))
 	}
+	return result
 }
 
 func (r *Room) AttackDirection(x1, y1, x2, y2 int) error {
@@ -256,7 +334,7 @@ func (r *Room) FindEmptySpace() (int, int) {
 	}
 
 	// pick a random empty space
-	randIndex := rand.Intn(len(emptySpaces))
+	randIndex := r.Rand.Intn(len(emptySpaces))
 	return emptySpaces[randIndex].X, emptySpaces[randIndex].Y
 }
 
@@ -298,8 +376,48 @@ func (r *Room) FindFarthestDistance(playerX, playerY int, skipWalls bool) (int,
 	return maxX, maxY
 }
 
+// FindFarthestReachable walks the room from the given coordinates and returns
+// the reachable empty space that takes the most steps to get to.
+func (r *Room) FindFarthestReachable(startX, startY int) (int, int) {
+	if startX < 0 || startX >= r.Width || startY < 0 || startY >= r.Height {
+		return -1, -1
+	}
+
+	visited := make([][]bool, r.Width)
+	for i := range visited {
+		visited[i] = make([]bool, r.Height)
+	}
+	visited[startX][startY] = true
+
+	farX, farY := -1, -1
+	queue := []*Coordinate{r.Grid[startX][startY]}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current.Entity.ID == entity.ObjEmpty {
+			farX, farY = current.X, current.Y
+		}
+
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				x, y := current.X+dx, current.Y+dy
+				if x < 0 || x >= r.Width || y < 0 || y >= r.Height || visited[x][y] {
+					continue
+				}
+				visited[x][y] = true
+				if r.Grid[x][y].Entity.ID != entity.ObjWall {
+					queue = append(queue, r.Grid[x][y])
+				}
+			}
+		}
+	}
+
+	return farX, farY
+}
+
 func (r *Room) AddRandomEntities(template entity.Character, entityCount int) []*entity.Character {
-	entities := make([]*entity.Character, entityCount)
+	entities := make([]*entity.Character, 0, entityCount)
 	for i := 0; i < entityCount; i++ {
 		entityX, entityY := r.FindEmptySpace()
 		if entityX == -1 && entityY == -1 {
@@ -307,13 +425,29 @@ func (r *Room) AddRandomEntities(template entity.Character, entityCount int) []*
 			continue
 		}
 
-		e := &entity.Character{}
-		*e = template
+		e := entity.NewEnemy(template)
 		e.X = entityX
 		e.Y = entityY
 		r.AddEntity(&Coordinate{X: entityX, Y: entityY, Entity: e})
-		entities[i] = e
+		entities = append(entities, e)
 	}
 
 	return entities
 }
+
+func (r *Room) AddRandomItems(template entity.Item, itemCount int) []*entity.Item {
+	items := make([]*entity.Item, 0, itemCount)
+	for i := 0; i < itemCount; i++ {
+		itemX, itemY := r.FindEmptySpace()
+		if itemX == -1 && itemY == -1 {
+			// can't find empty space so don't place the item
+			continue
+		}
+
+		item := entity.NewItem(template)
+		r.DropItem(itemX, itemY, item)
+		items = append(items, item)
+	}
+
+	return items
+}
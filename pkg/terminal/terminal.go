@@ -0,0 +1,74 @@
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultColumns = 80
+	DefaultRows    = 24
+)
+
+// Size returns the terminal dimensions in characters. It asks stty first,
+// then falls back to the COLUMNS and LINES variables and finally to a
+// classic 80x24 terminal.
+func Size() (int, int) {
+	if columns, rows, err := sttySize(); err == nil {
+		return columns, rows
+	}
+
+	columns, errColumns := strconv.Atoi(os.Getenv("COLUMNS"))
+	rows, errRows := strconv.Atoi(os.Getenv("LINES"))
+	if errColumns == nil && errRows == nil && columns > 0 && rows > 0 {
+		return columns, rows
+	}
+
+	return DefaultColumns, DefaultRows
+}
+
+func sttySize() (int, int, error) {
+	out, err := stty("size")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var rows, columns int
+	if _, err := fmt.Sscan(strings.TrimSpace(out), &rows, &columns); err != nil {
+		return 0, 0, err
+	}
+	if rows <= 0 || columns <= 0 {
+		return 0, 0, fmt.Errorf("invalid terminal size %d x %d", columns, rows)
+	}
+
+	return columns, rows, nil
+}
+
+// EnableKeyMode switches the terminal to deliver every keystroke as soon as
+// it is pressed, without echoing it. Output processing and signals such as
+// Ctrl-C keep working. The returned function restores the old settings.
+func EnableKeyMode() (func() error, error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("terminal does not support key mode: %w", err)
+	}
+
+	if _, err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return nil, fmt.Errorf("failed to enable key mode: %w", err)
+	}
+
+	return func() error {
+		_, err := stty(strings.TrimSpace(saved))
+		return err
+	}, nil
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}